@@ -0,0 +1,579 @@
+package matcher
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"unicode/utf8"
+)
+
+const (
+	// denseSize 小字符集时每个节点使用的固定数组大小
+	denseSize = 256
+	// maxAlphabet AC自动机支持的最大字符类别数（字符索引使用uint16存储，含 otherClass）
+	maxAlphabet = 1 << 16
+	// otherClass 不在模式串字符集中的字符所属的类别，任何节点都没有该类别的子节点
+	otherClass uint16 = 0
+)
+
+// ErrAlphabetTooLarge 模式串中不同字符的数量超过了AC自动机支持的上限
+var ErrAlphabetTooLarge = errors.New("matcher: pattern alphabet exceeds 65535 distinct runes")
+
+// ACNode AC自动机的节点结构
+//
+// 字符集不超过 denseSize 时子节点存放在固定大小数组中，按索引直接访问；
+// 字符集较大时改为按字符索引升序存放的紧凑切片，通过二分查找访问。
+type ACNode struct {
+	children *[denseSize]*ACNode // 小字符集使用固定大小数组替代map，提高访问速度
+	keys     []uint16            // 大字符集时子节点的字符索引（升序）
+	next     []*ACNode           // 与keys一一对应的子节点
+	fail     *ACNode             // 失败指针
+	isEnd    bool                // 是否是模式串的结尾
+	index    int                 // 该节点对应的模式串下标
+	output   []int               // 输出集合缓存（模式串下标）
+	id       int32               // 状态编号，编译DFA后有效
+}
+
+// NewACNode 创建新的AC节点，子节点使用紧凑切片存储
+func NewACNode() *ACNode {
+	return &ACNode{
+		fail:   nil,
+		isEnd:  false,
+		output: make([]int, 0, 4), // 预分配空间
+	}
+}
+
+// newDenseACNode 创建子节点使用固定大小数组存储的AC节点
+func newDenseACNode() *ACNode {
+	node := NewACNode()
+	node.children = new([denseSize]*ACNode)
+	return node
+}
+
+// child 返回字符索引对应的子节点，不存在时返回nil
+func (n *ACNode) child(index uint16) *ACNode {
+	if n.children != nil && index < denseSize {
+		return n.children[index]
+	}
+	// 二分查找紧凑切片
+	lo, hi := 0, len(n.keys)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if n.keys[mid] < index {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	if lo < len(n.keys) && n.keys[lo] == index {
+		return n.next[lo]
+	}
+	return nil
+}
+
+// setChild 设置字符索引对应的子节点
+func (n *ACNode) setChild(index uint16, child *ACNode) {
+	if n.children != nil && index < denseSize {
+		n.children[index] = child
+		return
+	}
+	pos, found := slices.BinarySearch(n.keys, index)
+	if found {
+		n.next[pos] = child
+		return
+	}
+	n.keys = slices.Insert(n.keys, pos, index)
+	n.next = slices.Insert(n.next, pos, child)
+}
+
+// removeChild 删除字符索引对应的子节点
+func (n *ACNode) removeChild(index uint16) {
+	if n.children != nil && index < denseSize {
+		n.children[index] = nil
+		return
+	}
+	if pos, found := slices.BinarySearch(n.keys, index); found {
+		n.keys = slices.Delete(n.keys, pos, pos+1)
+		n.next = slices.Delete(n.next, pos, pos+1)
+	}
+}
+
+// leaf 判断节点是否没有子节点
+func (n *ACNode) leaf() bool {
+	if len(n.keys) > 0 {
+		return false
+	}
+	if n.children != nil {
+		for _, child := range n.children {
+			if child != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// eachChild 按字符索引升序遍历所有子节点
+func (n *ACNode) eachChild(fn func(index uint16, child *ACNode)) {
+	if n.children != nil {
+		for i, child := range n.children {
+			if child != nil {
+				fn(uint16(i), child)
+			}
+		}
+	}
+	for i, index := range n.keys {
+		fn(index, n.next[i])
+	}
+}
+
+// AC 定义AC自动机结构
+type AC struct {
+	root     *ACNode
+	patterns []string      // 模式串集合，下标与 Match.Index 对应
+	lengths  []int         // 模式串长度缓存（按rune计算，启用大小写折叠或规范化时为变换后的长度）
+	maxLen   int           // 最长的模式串长度（同 lengths）
+	forms    []pinyinEntry // 模式串的拼音形式，输出集合中的编号 formID(k) 对应 forms[k]
+	bounds   boundaries    // 模式串的边界要求
+	dirty    bool          // 插入模式串后是否尚未重新构建失败指针
+	inc      *acIndex      // 增量修改使用的辅助索引，第一次增量修改时创建
+	// 添加字符映射缓存
+	charMap map[rune]uint16 // 字符到子节点索引的映射，索引0保留给 otherClass
+	maxChar int             // 字符类别数（含 otherClass）
+	dense   bool            // 是否使用固定大小数组存储子节点
+	// DFA转移表，编译后每个字符只需一次查表，nil表示使用失败指针进行状态转移
+	dfa    []int32
+	states []*ACNode // 按状态编号排列的节点
+	opts   options   // 构建配置
+}
+
+// NewAC 创建新的AC自动机
+func NewAC(opts ...Option) *AC {
+	return &AC{
+		root:    NewACNode(),
+		charMap: make(map[rune]uint16, 256),
+		maxChar: 1,
+		opts:    newOptions(opts),
+	}
+}
+
+// buildCharMap 构建字符映射，字符按码点升序从1开始分配索引
+func (ac *AC) buildCharMap(patterns []string) error {
+	charSet := make(map[rune]bool)
+	for _, pattern := range patterns {
+		for _, r := range ac.opts.foldString(pattern) {
+			charSet[r] = true
+		}
+	}
+	if len(charSet) >= maxAlphabet {
+		return ErrAlphabetTooLarge
+	}
+
+	chars := make([]rune, 0, len(charSet))
+	for r := range charSet {
+		chars = append(chars, r)
+	}
+	slices.Sort(chars)
+	for i, r := range chars {
+		ac.charMap[r] = uint16(i + 1)
+	}
+	ac.maxChar = len(chars) + 1
+	ac.dense = ac.maxChar <= denseSize
+	return nil
+}
+
+// newNode 根据字符集大小创建合适布局的节点
+func (ac *AC) newNode() *ACNode {
+	if ac.dense {
+		return newDenseACNode()
+	}
+	return NewACNode()
+}
+
+// charIndex 返回字符的索引，字符不在映射中时为其分配新的索引
+func (ac *AC) charIndex(r rune) (uint16, error) {
+	if index, ok := ac.charMap[r]; ok {
+		return index, nil
+	}
+	if ac.maxChar >= maxAlphabet {
+		return 0, ErrAlphabetTooLarge
+	}
+	index := uint16(ac.maxChar)
+	ac.charMap[r] = index
+	ac.maxChar++
+	return index, nil
+}
+
+// Insert 向AC自动机中插入一个模式串，模式串下标为已插入的模式串数量
+// 模式串中的新字符会被追加到字符映射中，字符集超过上限时返回 ErrAlphabetTooLarge
+func (ac *AC) Insert(pattern string) error {
+	ac.patterns = append(ac.patterns, pattern)
+	ac.lengths = append(ac.lengths, len(ac.opts.foldString(pattern)))
+	ac.bounds = ac.opts.appendBoundaries(ac.bounds, pattern)
+	index := len(ac.patterns) - 1
+	if err := ac.insert(pattern, index); err != nil {
+		return err
+	}
+	return ac.insertForms(ac.opts.expandPinyin(ac.patterns[index:]), index)
+}
+
+// insertForms 插入拼音形式，offset为拼音形式对应的第一个模式串的下标
+func (ac *AC) insertForms(forms []pinyinEntry, offset int) error {
+	for _, e := range forms {
+		e.index += offset
+		ac.forms = append(ac.forms, e)
+		if err := ac.insert(e.text, formID(len(ac.forms)-1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insert 将下标为index的模式串插入Trie树，重复的模式串保留第一次出现的下标
+func (ac *AC) insert(pattern string, index int) error {
+	// 插入后失败指针需要重新构建，已编译的DFA失效
+	ac.dropDFA()
+	ac.dirty = true
+	ac.inc = nil
+	node := ac.root
+	runes := ac.opts.foldString(pattern)
+	if len(runes) == 0 {
+		// 变换后为空的模式串（例如只由可忽略字符组成）不会产生匹配
+		return nil
+	}
+	ac.maxLen = max(ac.maxLen, len(runes))
+	for _, r := range runes {
+		class, err := ac.charIndex(r)
+		if err != nil {
+			return err
+		}
+		child := node.child(class)
+		if child == nil {
+			child = ac.newNode()
+			node.setChild(class, child)
+		}
+		node = child
+	}
+	if !node.isEnd {
+		node.isEnd = true
+		node.index = index
+	}
+	return nil
+}
+
+// BuildFail 构建失败指针（预处理）
+func (ac *AC) BuildFail() {
+	ac.dropDFA()
+	ac.dirty = false
+	ac.inc = nil
+	// 使用切片替代通用队列，提高性能
+	queue := make([]*ACNode, 0, 256)
+	queue = append(queue, ac.root)
+	ac.root.fail = ac.root
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		// 处理当前节点的所有子节点，重新构建时丢弃之前的输出集合
+		current.eachChild(func(i uint16, child *ACNode) {
+			child.output = child.output[:0]
+			if current == ac.root {
+				child.fail = ac.root
+			} else {
+				failNode := current.fail
+				for failNode != ac.root && failNode.child(i) == nil {
+					failNode = failNode.fail
+				}
+				if next := failNode.child(i); next != nil {
+					child.fail = next
+					// 合并输出集合
+					child.output = append(child.output, child.fail.output...)
+				} else {
+					child.fail = ac.root
+				}
+			}
+			if child.isEnd {
+				child.output = append(child.output, child.index)
+			}
+			queue = append(queue, child)
+		})
+	}
+}
+
+// Build 使用模式串集合构建AC自动机，会丢弃之前构建的内容
+// 字符集超过上限时返回 ErrAlphabetTooLarge
+func (ac *AC) Build(patterns []string) error {
+	ac.charMap = make(map[rune]uint16, 256)
+	forms := ac.opts.expandPinyin(patterns)
+	texts := patterns
+	if len(forms) > 0 {
+		texts = slices.Clone(patterns)
+		for _, e := range forms {
+			texts = append(texts, e.text)
+		}
+	}
+	// 首先构建字符映射
+	if err := ac.buildCharMap(texts); err != nil {
+		return err
+	}
+	ac.root = ac.newNode()
+	ac.patterns = slices.Clone(patterns)
+	ac.lengths = make([]int, len(patterns))
+	ac.maxLen = 0
+	ac.forms = nil
+	ac.bounds = ac.opts.appendBoundaries(nil, patterns...)
+	// 插入所有模式串
+	for i, pattern := range patterns {
+		ac.lengths[i] = len(ac.opts.foldString(pattern))
+		if pattern == "" {
+			continue
+		}
+		if err := ac.insert(pattern, i); err != nil {
+			return err
+		}
+	}
+	// 拼音形式在所有模式串之后插入，与模式串相同的拼音形式不会覆盖模式串
+	if err := ac.insertForms(forms, 0); err != nil {
+		return err
+	}
+	// 构建失败指针
+	ac.BuildFail()
+	return nil
+}
+
+// BuildAC 预处理构建AC自动机
+func BuildAC(patterns []string, opts ...Option) (*AC, error) {
+	ac := NewAC(opts...)
+	if err := ac.Build(patterns); err != nil {
+		return nil, err
+	}
+	return ac, nil
+}
+
+// charClass 返回文本字符所属的类别，不在字符集中的字符返回 otherClass
+func (ac *AC) charClass(r rune) uint16 {
+	if index, ok := ac.charMap[r]; ok {
+		return index
+	}
+	return otherClass
+}
+
+// findNextState 查找下一个状态（优化的状态转移）
+func (ac *AC) findNextState(current *ACNode, r rune) *ACNode {
+	index := ac.charClass(r)
+	// 字符集之外的字符无法被任何状态接受，直接回到根节点
+	if index == otherClass {
+		return ac.root
+	}
+	if ac.dfa != nil {
+		return ac.states[ac.dfa[int(current.id)*ac.maxChar+int(index)]]
+	}
+	for current != ac.root && current.child(index) == nil {
+		current = current.fail
+	}
+	if next := current.child(index); next != nil {
+		return next
+	}
+	return ac.root
+}
+
+// Search 在文本中搜索所有模式串出现的位置
+// 匹配语义不是 MatchAll 时只返回选中的不重叠匹配
+func (ac *AC) Search(text string) map[string][]int {
+	result := make(map[string][]int)
+	if ac.opts.kind != MatchAll || ac.opts.generic() || len(ac.forms) > 0 {
+		for _, m := range ac.FindAll(text) {
+			result[m.Pattern] = append(result[m.Pattern], m.RuneStart)
+		}
+		return result
+	}
+	runes := []rune(text)
+	current := ac.root
+
+	// 预分配结果空间
+	posCache := make([]int, 0, 64)
+	patternCache := make([]int, 0, 64)
+
+	// 遍历文本
+	for i, r := range runes {
+		current = ac.findNextState(current, r)
+
+		// 使用预计算的输出集合
+		if len(current.output) > 0 {
+			for _, index := range current.output {
+				patternCache = append(patternCache, index)
+				posCache = append(posCache, i-ac.lengths[index]+1)
+			}
+		}
+	}
+
+	// 批量处理结果
+	for i, index := range patternCache {
+		pattern := ac.patterns[index]
+		if result[pattern] == nil {
+			result[pattern] = make([]int, 0, 8)
+		}
+		result[pattern] = append(result[pattern], posCache[i])
+	}
+
+	return result
+}
+
+// match 根据输出集合中的编号和结束位置构造匹配结果
+func (ac *AC) match(id, end, runeEnd int) Match {
+	if id < 0 {
+		e := ac.forms[formID(id)]
+		return Match{
+			Index:     e.index,
+			Pattern:   ac.patterns[e.index],
+			Start:     end - len(e.text),
+			End:       end,
+			RuneStart: runeEnd - e.length,
+			RuneEnd:   runeEnd,
+			Form:      e.form,
+		}
+	}
+	return Match{
+		Index:     id,
+		Pattern:   ac.patterns[id],
+		Start:     end - len(ac.patterns[id]),
+		End:       end,
+		RuneStart: runeEnd - ac.lengths[id],
+		RuneEnd:   runeEnd,
+	}
+}
+
+// FindFirst 返回文本中最先出现的匹配
+func (ac *AC) FindFirst(text string) (Match, bool) {
+	if ac.opts.kind != MatchAll {
+		// 不重叠语义下最先的匹配即结果中的第一个
+		if matches := ac.FindAll(text); len(matches) > 0 {
+			return matches[0], true
+		}
+		return Match{}, false
+	}
+	if ac.opts.generic() {
+		var best Match
+		found := false
+		ac.scanFold(text, nil, func(m Match) bool {
+			// 扫描按结束位置升序进行，只需比较第一个结束位置上的匹配
+			if found && m.End != best.End {
+				return false
+			}
+			if better(m, best, found) {
+				best, found = m, true
+			}
+			return true
+		})
+		return best, found
+	}
+	current := ac.root
+	runeEnd := 0
+	for end := 0; end < len(text); {
+		r, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+		runeEnd++
+		current = ac.findNextState(current, r)
+		if len(current.output) > 0 {
+			// 输出集合中最后一个模式串最长
+			index := current.output[len(current.output)-1]
+			return ac.match(index, end, runeEnd), true
+		}
+	}
+	return Match{}, false
+}
+
+// Each 按扫描顺序（结束位置升序）对每个匹配调用fn，fn返回false时停止扫描
+// 总是报告所有重叠的匹配，不受匹配语义影响。未启用字符变换和边界要求时扫描过程不分配内存。
+func (ac *AC) Each(text string, fn func(Match) bool) {
+	ac.each(text, nil, fn)
+}
+
+// each 同 Each，check不为nil时在消费位于字节偏移pos的字符之前调用check(pos)，返回false时停止扫描
+func (ac *AC) each(text string, check func(pos int) bool, fn func(Match) bool) {
+	if ac.opts.generic() {
+		ac.scanFold(text, check, fn)
+		return
+	}
+	current := ac.root
+	runeEnd := 0
+	for end := 0; end < len(text); {
+		if check != nil && !check(end) {
+			return
+		}
+		r, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+		runeEnd++
+		current = ac.findNextState(current, r)
+		for _, index := range current.output {
+			if !fn(ac.match(index, end, runeEnd)) {
+				return
+			}
+		}
+	}
+}
+
+// Matches 返回按扫描顺序产生所有重叠匹配的迭代器，同 Each
+func (ac *AC) Matches(text string) iter.Seq[Match] {
+	return func(yield func(Match) bool) {
+		ac.Each(text, yield)
+	}
+}
+
+// FindAll 按匹配语义返回文本中的匹配
+func (ac *AC) FindAll(text string) []Match {
+	var result []Match
+	ac.Each(text, func(m Match) bool {
+		result = append(result, m)
+		return true
+	})
+	return resolveMatches(result, ac.opts.kind)
+}
+
+// Contains 判断文本中是否包含任一模式串
+func (ac *AC) Contains(text string) bool {
+	if ac.opts.generic() {
+		found := false
+		ac.scanFold(text, nil, func(Match) bool {
+			found = true
+			return false
+		})
+		return found
+	}
+	current := ac.root
+	for _, r := range text {
+		current = ac.findNextState(current, r)
+		if len(current.output) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// scanFold 启用大小写折叠、规范化、边界要求或拼音形式时使用通用扫描路径，见 foldScan.scan
+func (ac *AC) scanFold(text string, check func(pos int) bool, fn func(Match) bool) {
+	f := ac.folder()
+	f.scan(text, check, fn)
+}
+
+// foldStep 通用扫描路径的单步状态转移，见 foldScan.step
+func (ac *AC) foldStep(current *ACNode, ring *foldRing, r rune, start, end, runeStart int, fn func(Match) bool) (*ACNode, bool) {
+	f := ac.folder()
+	return f.step(current, ring, r, start, end, runeStart, fn)
+}
+
+// folder 返回通用扫描路径使用的状态转移函数和模式串信息
+func (ac *AC) folder() foldScan[*ACNode] {
+	return foldScan[*ACNode]{
+		opts:     &ac.opts,
+		root:     ac.root,
+		next:     ac.findNextState,
+		output:   func(n *ACNode) []int { return n.output },
+		patterns: ac.patterns,
+		lengths:  ac.lengths,
+		maxLen:   ac.maxLen,
+		forms:    ac.forms,
+		bounds:   ac.bounds,
+	}
+}
@@ -0,0 +1,64 @@
+package matcher
+
+// DefaultDFAMemory CompileDFA 默认的转移表内存上限（字节）
+const DefaultDFAMemory = 64 << 20
+
+// CompileDFA 将goto函数和失败指针展开为完整的DFA转移表
+//
+// 编译后搜索时每个输入字符只需要一次查表即可得到下一个状态，不再沿失败指针回溯。
+// 转移表大小为 状态数 × 字符类别数 × 4 字节，超过maxBytes时不进行编译，
+// 继续使用失败指针进行状态转移并返回false。maxBytes小于等于0时使用 DefaultDFAMemory。
+// 插入模式串后尚未构建失败指针时先调用 BuildFail，之后再插入模式串会使转移表失效。
+func (ac *AC) CompileDFA(maxBytes int) bool {
+	if maxBytes <= 0 {
+		maxBytes = DefaultDFAMemory
+	}
+	if ac.dirty || ac.root.fail == nil {
+		ac.BuildFail()
+	}
+
+	// 按层次遍历为节点编号，保证失败指针指向的状态先于当前状态编号
+	states := make([]*ACNode, 0, 256)
+	states = append(states, ac.root)
+	ac.root.id = 0
+	for i := 0; i < len(states); i++ {
+		states[i].eachChild(func(_ uint16, child *ACNode) {
+			child.id = int32(len(states))
+			states = append(states, child)
+		})
+	}
+
+	width := ac.maxChar
+	if len(states)*width*4 > maxBytes {
+		ac.dropDFA()
+		return false
+	}
+
+	// otherClass 对应的第0列始终为根节点，无需填充
+	dfa := make([]int32, len(states)*width)
+	for _, state := range states {
+		row := dfa[int(state.id)*width : int(state.id+1)*width]
+		if state != ac.root {
+			// 没有直接转移的字符沿用失败指针状态的转移
+			copy(row, dfa[int(state.fail.id)*width:int(state.fail.id+1)*width])
+		}
+		state.eachChild(func(class uint16, child *ACNode) {
+			row[class] = child.id
+		})
+	}
+
+	ac.dfa = dfa
+	ac.states = states
+	return true
+}
+
+// IsDFA 返回是否已编译为DFA转移表
+func (ac *AC) IsDFA() bool {
+	return ac.dfa != nil
+}
+
+// dropDFA 丢弃已编译的DFA转移表，恢复使用失败指针进行状态转移
+func (ac *AC) dropDFA() {
+	ac.dfa = nil
+	ac.states = nil
+}
@@ -0,0 +1,165 @@
+package matcher
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// 生成包含count个不同汉字的模式串集合，每个模式串由相邻的两个字符组成
+func generateCJKPatterns(count int) []string {
+	patterns := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		patterns = append(patterns, string([]rune{rune(0x4e00 + i), rune(0x4e00 + i + 1)}))
+	}
+	return patterns
+}
+
+// 测试大字符集下AC自动机的结果与Trie树一致
+func TestACLargeAlphabet(t *testing.T) {
+	tests := []struct {
+		name  string
+		count int
+	}{
+		{name: "小字符集", count: 100},
+		{name: "刚好超过256", count: 256},
+		{name: "数千个汉字", count: 5000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patterns := generateCJKPatterns(tt.count)
+			ac, err := BuildAC(patterns)
+			if err != nil {
+				t.Fatalf("BuildAC() error = %v", err)
+			}
+			trie := BuildTrie(patterns)
+
+			// 文本覆盖字符集的首尾以及超出字符集的字符
+			text := string([]rune{0x4e00, 0x4e01, 0x4e02, '!', rune(0x4e00 + tt.count - 1), rune(0x4e00 + tt.count), 0x4e00})
+			got := ac.Search(text)
+			want := trie.Search(text)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AC.Search() = %v, Trie.Search() = %v", got, want)
+			}
+		})
+	}
+}
+
+// 测试字符集超过上限时返回错误
+func TestACAlphabetTooLarge(t *testing.T) {
+	patterns := make([]string, 0, maxAlphabet+1)
+	for i := 0; i <= maxAlphabet; i++ {
+		patterns = append(patterns, string(rune(0x10000+i)))
+	}
+	if _, err := BuildAC(patterns); !errors.Is(err, ErrAlphabetTooLarge) {
+		t.Errorf("BuildAC() error = %v, want %v", err, ErrAlphabetTooLarge)
+	}
+
+	// 逐个插入超出上限的字符同样返回错误
+	ac := NewAC()
+	var err error
+	for _, pattern := range patterns {
+		if err = ac.Insert(pattern); err != nil {
+			break
+		}
+	}
+	if !errors.Is(err, ErrAlphabetTooLarge) {
+		t.Errorf("Insert() error = %v, want %v", err, ErrAlphabetTooLarge)
+	}
+}
+
+// 测试编译DFA前后搜索结果一致
+func TestACCompileDFA(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		patterns []string
+	}{
+		{
+			name:     "经典示例",
+			text:     "ushers",
+			patterns: []string{"he", "she", "his", "hers"},
+		},
+		{
+			name:     "模式串后缀重叠",
+			text:     generateRepeatedText("测试测试测和测试测试测试", 10),
+			patterns: []string{"试测", "试测试", "试测试测", "试测试测试"},
+		},
+		{
+			name:     "外来字符",
+			text:     "一个世界，另一个世界，乙界😀👋🌍你👋世界🌍",
+			patterns: []string{"世界", "界", "👋世界", "世界🌍"},
+		},
+		{
+			name:     "大字符集",
+			text:     string([]rune{0x4e00, 0x4e01, 0x4e02, '!', 0x4e00 + 299, 0x4e00 + 300}),
+			patterns: generateCJKPatterns(300),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ac, err := BuildAC(tc.patterns)
+			if err != nil {
+				t.Fatalf("BuildAC() error = %v", err)
+			}
+			want := ac.FindAll(tc.text)
+			if !ac.CompileDFA(0) || !ac.IsDFA() {
+				t.Fatalf("CompileDFA() = false, want true")
+			}
+			if got := ac.FindAll(tc.text); !reflect.DeepEqual(got, want) {
+				t.Errorf("FindAll() with DFA = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+// 测试插入模式串后未构建失败指针时编译DFA
+func TestACCompileDFAAfterInsert(t *testing.T) {
+	ac, err := BuildAC([]string{"ab"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ac.Insert("xyz"); err != nil {
+		t.Fatal(err)
+	}
+	if !ac.CompileDFA(0) {
+		t.Fatal("CompileDFA() = false, want true")
+	}
+	fresh, _ := BuildAC([]string{"ab", "xyz"})
+	if got, want := ac.FindAll("abxyz"), fresh.FindAll("abxyz"); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll() = %+v, want %+v", got, want)
+	}
+
+	// 未调用 Build 直接插入
+	ac = NewAC()
+	if err := ac.Insert("ab"); err != nil {
+		t.Fatal(err)
+	}
+	if !ac.CompileDFA(0) || len(ac.FindAll("xab")) != 1 {
+		t.Errorf("FindAll() = %+v", ac.FindAll("xab"))
+	}
+}
+
+// 测试转移表超过内存上限时回退到失败指针
+func TestACCompileDFABudget(t *testing.T) {
+	patterns := []string{"he", "she", "his", "hers"}
+	ac, _ := BuildAC(patterns)
+	if ac.CompileDFA(16) {
+		t.Errorf("CompileDFA(16) = true, want false")
+	}
+	if ac.IsDFA() {
+		t.Errorf("IsDFA() = true after failed compile")
+	}
+	if got := ac.Search("ushers"); !reflect.DeepEqual(got, map[string][]int{"she": {1}, "he": {2}, "hers": {2}}) {
+		t.Errorf("Search() = %v", got)
+	}
+
+	// 插入新的模式串后转移表失效
+	ac.CompileDFA(0)
+	ac.Insert("us")
+	if ac.IsDFA() {
+		t.Errorf("IsDFA() = true after Insert")
+	}
+}
@@ -0,0 +1,128 @@
+package matcher
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"runtime"
+)
+
+// BatchResult 批量扫描中一个文档的结果
+type BatchResult struct {
+	Index   int     // 文档在输入中的序号，从0开始
+	Matches []Match // 按匹配语义返回的匹配，同 FindAll
+	Err     error   // 扫描该文档时发生的错误，例如配置中的回调函数panic
+}
+
+// ScanBatch 使用workers个goroutine并发扫描docs中的文档，按输入顺序返回每个文档的结果
+// workers不大于0时使用 runtime.GOMAXPROCS(0)。
+//
+// 同时处理的文档不超过 2*workers+1 个，调用方不读取结果时不会继续从docs读取文档。
+// docs关闭且所有结果都已返回后，或者ctx结束后，结果通道被关闭；ctx结束时剩余的文档不再扫描，
+// 调用方可以通过 ctx.Err 区分这两种情况。自动机在扫描期间不能被修改。
+func (a *ACTree) ScanBatch(ctx context.Context, docs <-chan string, workers int) <-chan BatchResult {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	type job struct {
+		index  int
+		text   string
+		result chan<- BatchResult
+	}
+	jobs := make(chan job)
+	// 按输入顺序排列的结果通道，容量限制了已读取但尚未返回的文档数量
+	pending := make(chan chan BatchResult, workers)
+	out := make(chan BatchResult)
+
+	for range workers {
+		go func() {
+			for j := range jobs {
+				j.result <- a.scanDocument(j.index, j.text)
+			}
+		}()
+	}
+
+	// 分发文档，先登记结果通道再交给worker，保证结果按输入顺序返回
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+		for index := 0; ; index++ {
+			var text string
+			select {
+			case <-ctx.Done():
+				return
+			case doc, ok := <-docs:
+				if !ok {
+					return
+				}
+				text = doc
+			}
+			result := make(chan BatchResult, 1)
+			select {
+			case <-ctx.Done():
+				return
+			case pending <- result:
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- job{index: index, text: text, result: result}:
+			}
+		}
+	}()
+
+	go func() {
+		defer close(out)
+		for result := range pending {
+			select {
+			case <-ctx.Done():
+				return
+			case r := <-result:
+				select {
+				case <-ctx.Done():
+					return
+				case out <- r:
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// ScanSeq 与 ScanBatch 相同，但从迭代器读取文档并以迭代器返回结果
+// 提前结束迭代时停止扫描。docs在单独的goroutine中迭代，停止后在下一次产生文档时返回
+func (a *ACTree) ScanSeq(ctx context.Context, docs iter.Seq[string], workers int) iter.Seq[BatchResult] {
+	return func(yield func(BatchResult) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		in := make(chan string)
+		go func() {
+			defer close(in)
+			for doc := range docs {
+				select {
+				case <-ctx.Done():
+					return
+				case in <- doc:
+				}
+			}
+		}()
+		for r := range a.ScanBatch(ctx, in, workers) {
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// scanDocument 扫描一个文档，将扫描过程中的panic转换为该文档的错误
+func (a *ACTree) scanDocument(index int, text string) (result BatchResult) {
+	result.Index = index
+	defer func() {
+		if r := recover(); r != nil {
+			result.Matches = nil
+			result.Err = fmt.Errorf("matcher: scanning document %d: %v", index, r)
+		}
+	}()
+	result.Matches = a.FindAll(text)
+	return result
+}
@@ -0,0 +1,99 @@
+package matcher
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestScanBatch(t *testing.T) {
+	tree := NewAc()
+	if err := tree.Build([]string{"he", "she", "敏感词"}); err != nil {
+		t.Fatal(err)
+	}
+	docs := make([]string, 1000)
+	for i := range docs {
+		docs[i] = fmt.Sprintf("%d ushers %s", i, strings.Repeat("敏感词", i%5))
+	}
+	in := make(chan string)
+	go func() {
+		defer close(in)
+		for _, doc := range docs {
+			in <- doc
+		}
+	}()
+	i := 0
+	for r := range tree.ScanBatch(context.Background(), in, 4) {
+		if r.Index != i || r.Err != nil || !reflect.DeepEqual(r.Matches, tree.FindAll(docs[i])) {
+			t.Fatalf("result %d = %+v", i, r)
+		}
+		i++
+	}
+	if i != len(docs) {
+		t.Errorf("got %d results, want %d", i, len(docs))
+	}
+
+	// 迭代器输入，提前结束迭代
+	var got []int
+	for r := range tree.ScanSeq(context.Background(), slices.Values(docs), 0) {
+		got = append(got, r.Index)
+		if len(got) == 10 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(got, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("ScanSeq() indexes = %v", got)
+	}
+}
+
+// 单个文档扫描失败不影响其他文档
+func TestScanBatchError(t *testing.T) {
+	tree := NewAc(WithIgnorable(func(r rune) bool {
+		if r == '!' {
+			panic("bad rune")
+		}
+		return r == ' '
+	}, 1))
+	if err := tree.Build([]string{"ab"}); err != nil {
+		t.Fatal(err)
+	}
+	docs := []string{"a b", "a!b", "ab"}
+	var results []BatchResult
+	for r := range tree.ScanSeq(context.Background(), slices.Values(docs), 2) {
+		results = append(results, r)
+	}
+	if len(results) != 3 || results[1].Err == nil || results[0].Err != nil || len(results[2].Matches) != 1 {
+		t.Errorf("results = %+v", results)
+	}
+}
+
+func TestScanBatchCancel(t *testing.T) {
+	tree := NewAc()
+	if err := tree.Build([]string{"a"}); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	in := make(chan string) // 永远不关闭
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case in <- "aaa":
+			}
+		}
+	}()
+	n := 0
+	for range tree.ScanBatch(ctx, in, 3) {
+		if n++; n == 100 {
+			cancel()
+		}
+	}
+	if n < 100 || ctx.Err() == nil {
+		t.Errorf("got %d results after cancel", n)
+	}
+}
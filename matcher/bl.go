@@ -0,0 +1,221 @@
+package matcher
+
+import "unicode/utf8"
+
+// BruteForceMatch 使用暴力匹配算法查找模式串在主串中的位置
+// 参数:
+//   text: 主串
+//   pattern: 模式串
+// 返回值:
+//   找到则返回第一次匹配的起始位置（按rune计算），未找到返回-1
+func BruteForceMatch(text string, pattern string) int {
+	// 获取主串和模式串的长度（按rune计算）
+	n := utf8.RuneCountInString(text)
+	m := utf8.RuneCountInString(pattern)
+
+	// 如果模式串为空，返回0
+	if m == 0 {
+		return 0
+	}
+
+	// 如果模式串长度大于主串，不可能匹配成功
+	if m > n {
+		return -1
+	}
+
+	// 将字符串转换为rune切片，以支持中文
+	textRunes := []rune(text)
+	patternRunes := []rune(pattern)
+
+	return bruteForceIndex(textRunes, patternRunes, 0)
+}
+
+// BruteForceMatchAll 使用暴力匹配算法查找模式串在主串中出现的所有位置（允许重叠）
+// 返回所有匹配的起始位置（按rune计算），模式串为空时返回nil
+func BruteForceMatchAll(text string, pattern string) []int {
+	if pattern == "" {
+		return nil
+	}
+	return bruteForceIndexAll([]rune(text), []rune(pattern))
+}
+
+// bruteForceIndex 从from位置开始查找模式串第一次出现的位置
+func bruteForceIndex(textRunes, patternRunes []rune, from int) int {
+	n, m := len(textRunes), len(patternRunes)
+	// 外层循环遍历主串的每个可能的起始位置
+	for i := from; i <= n-m; i++ {
+		j := 0
+		// 内层循环比较从i开始的子串是否与模式串匹配
+		for j < m && textRunes[i+j] == patternRunes[j] {
+			j++
+		}
+		// 如果j等于模式串长度，说明完全匹配
+		if j == m {
+			return i
+		}
+	}
+
+	// 未找到匹配，返回-1
+	return -1
+}
+
+// bruteForceIndexAll 查找模式串出现的所有位置
+func bruteForceIndexAll(textRunes, patternRunes []rune) []int {
+	var result []int
+	for i := bruteForceIndex(textRunes, patternRunes, 0); i != -1; i = bruteForceIndex(textRunes, patternRunes, i+1) {
+		result = append(result, i)
+	}
+	return result
+}
+
+// BruteForce 基于暴力匹配算法的多模式串匹配器，逐个模式串进行匹配
+type BruteForce struct {
+	patterns [][]rune // 模式串的rune切片（启用大小写折叠或规范化时为变换后的字符）
+	values   []string // 模式串原始值
+	indexes  []int    // 模式串在构建时传入的模式串集合中的下标
+	opts     options  // 构建配置，使用匹配语义和字符变换
+}
+
+// NewBruteForce 创建新的暴力匹配器
+func NewBruteForce(opts ...Option) *BruteForce {
+	return &BruteForce{opts: newOptions(opts)}
+}
+
+// Build 构建暴力匹配器
+// 不支持拼音形式和边界要求，配置了 WithPinyin、WithBoundary 或 WithPatternBoundary 时返回 ErrUnsupportedOption
+func (bf *BruteForce) Build(patterns []string) error {
+	if err := bf.opts.noPinyin("BruteForce"); err != nil {
+		return err
+	}
+	if err := bf.opts.noBoundary("BruteForce"); err != nil {
+		return err
+	}
+	bf.patterns = make([][]rune, 0, len(patterns))
+	bf.values = make([]string, 0, len(patterns))
+	bf.indexes = make([]int, 0, len(patterns))
+	seen := make(map[string]bool, len(patterns))
+	for i, pattern := range patterns {
+		folded := bf.opts.foldString(pattern)
+		key := string(folded)
+		// 忽略空模式串和变换后为空的模式串（例如只由可忽略字符组成），重复的模式串保留第一次出现的下标
+		if len(folded) == 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		bf.patterns = append(bf.patterns, folded)
+		bf.values = append(bf.values, pattern)
+		bf.indexes = append(bf.indexes, i)
+	}
+	return nil
+}
+
+// FindFirst 返回文本中最先出现的匹配
+func (bf *BruteForce) FindFirst(text string) (Match, bool) {
+	if bf.opts.kind != MatchAll {
+		// 不重叠语义下最先的匹配即结果中的第一个
+		if matches := bf.FindAll(text); len(matches) > 0 {
+			return matches[0], true
+		}
+		return Match{}, false
+	}
+	if bf.opts.transformed() {
+		return bf.findFirstFold(text)
+	}
+	textRunes := []rune(text)
+	offsets := runeOffsets(text, len(textRunes))
+	var best Match
+	found := false
+	for i, p := range bf.patterns {
+		pos := bruteForceIndex(textRunes, p, 0)
+		if pos == -1 {
+			continue
+		}
+		m := bf.match(i, pos, offsets)
+		if better(m, best, found) {
+			best, found = m, true
+		}
+	}
+	return best, found
+}
+
+// FindAll 按匹配语义返回文本中的匹配
+func (bf *BruteForce) FindAll(text string) []Match {
+	var result []Match
+	if bf.opts.transformed() {
+		for i := range bf.patterns {
+			result = bf.appendAllFold(result, text, i)
+		}
+		return resolveMatches(result, bf.opts.kind)
+	}
+	textRunes := []rune(text)
+	offsets := runeOffsets(text, len(textRunes))
+	for i, p := range bf.patterns {
+		for _, pos := range bruteForceIndexAll(textRunes, p) {
+			result = append(result, bf.match(i, pos, offsets))
+		}
+	}
+	return resolveMatches(result, bf.opts.kind)
+}
+
+// match 根据第i个模式串的起始位置（按rune计算）构造匹配结果
+func (bf *BruteForce) match(i, pos int, offsets []int) Match {
+	end := pos + len(bf.patterns[i])
+	return Match{
+		Index:     bf.indexes[i],
+		Pattern:   bf.values[i],
+		Start:     offsets[pos],
+		End:       offsets[end],
+		RuneStart: pos,
+		RuneEnd:   end,
+	}
+}
+
+// Contains 判断文本中是否包含任一模式串
+func (bf *BruteForce) Contains(text string) bool {
+	if bf.opts.transformed() {
+		_, found := bf.findFirstFold(text)
+		return found
+	}
+	textRunes := []rune(text)
+	for _, p := range bf.patterns {
+		if bruteForceIndex(textRunes, p, 0) != -1 {
+			return true
+		}
+	}
+	return false
+}
+
+// indexFold 启用大小写折叠或规范化时查找第i个模式串从字节偏移from（rune偏移runeFrom）开始第一次出现的位置
+func (bf *BruteForce) indexFold(text string, i, from, runeFrom int) (Match, bool) {
+	for start, runeStart := from, runeFrom; start < len(text); runeStart++ {
+		if end, runeEnd, ok := foldMatchAt(&bf.opts, text, start, runeStart, bf.patterns[i]); ok {
+			return Match{Index: bf.indexes[i], Pattern: bf.values[i], Start: start, End: end, RuneStart: runeStart, RuneEnd: runeEnd}, true
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		start += size
+	}
+	return Match{}, false
+}
+
+// findFirstFold 启用大小写折叠或规范化时返回文本中最先出现的匹配
+func (bf *BruteForce) findFirstFold(text string) (Match, bool) {
+	var best Match
+	found := false
+	for i := range bf.patterns {
+		if m, ok := bf.indexFold(text, i, 0, 0); ok && better(m, best, found) {
+			best, found = m, true
+		}
+	}
+	return best, found
+}
+
+// appendAllFold 启用大小写折叠或规范化时将第i个模式串出现的所有位置追加到result
+func (bf *BruteForce) appendAllFold(result []Match, text string, i int) []Match {
+	m, ok := bf.indexFold(text, i, 0, 0)
+	for ok {
+		result = append(result, m)
+		_, size := utf8.DecodeRuneInString(text[m.Start:])
+		m, ok = bf.indexFold(text, i, m.Start+size, m.RuneStart+1)
+	}
+	return result
+}
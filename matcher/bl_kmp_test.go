@@ -1,4 +1,4 @@
-package main
+package matcher
 
 import (
 	"strconv"
@@ -118,7 +118,7 @@ func BenchmarkStringMatch(b *testing.B) {
 
 	// 预处理KMP的next数组
 	for i := range testCases {
-		testCases[i].next = getNext(testCases[i].pattern)
+		testCases[i].next = GetNext(testCases[i].pattern)
 	}
 
 	// 对每个测试用例分别运行BF和KMP算法的基准测试
@@ -178,7 +178,7 @@ func TestMatchConsistency1(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			next := getNext(tc.pattern)
+			next := GetNext(tc.pattern)
 			bfResult := BruteForceMatch(tc.text, tc.pattern)
 			kmpResult := KMPMatch(tc.text, tc.pattern, next)
 
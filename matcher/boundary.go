@@ -0,0 +1,183 @@
+package matcher
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Boundary 模式串的边界要求，可以按位组合
+//
+// 单词字符为字母、数字、组合符号和连接符（如 "_"），但汉字、假名、泰文等不使用空格分词的文字
+// 每个字符自成一个单词，因此 "中test文" 中的 "test" 满足单词边界，"testing" 中的 "test" 不满足。
+type Boundary uint8
+
+const (
+	// BoundaryWordStart 匹配不能从单词中间开始
+	BoundaryWordStart Boundary = 1 << iota
+	// BoundaryWordEnd 匹配不能在单词中间结束
+	BoundaryWordEnd
+	// BoundaryLineStart 匹配必须位于行首（文本开头或换行符之后）
+	BoundaryLineStart
+	// BoundaryLineEnd 匹配必须位于行尾（文本结尾或换行符之前）
+	BoundaryLineEnd
+	// BoundaryTextStart 匹配必须位于文本开头
+	BoundaryTextStart
+	// BoundaryTextEnd 匹配必须位于文本结尾
+	BoundaryTextEnd
+
+	// BoundaryNone 没有边界要求（默认）
+	BoundaryNone Boundary = 0
+	// BoundaryWord 全词匹配
+	BoundaryWord = BoundaryWordStart | BoundaryWordEnd
+	// BoundaryLine 整行匹配
+	BoundaryLine = BoundaryLineStart | BoundaryLineEnd
+	// BoundaryText 整个文本匹配
+	BoundaryText = BoundaryTextStart | BoundaryTextEnd
+
+	// boundaryEnd 需要知道匹配之后的字符才能判断的边界要求
+	boundaryEnd = BoundaryWordEnd | BoundaryLineEnd | BoundaryTextEnd
+)
+
+// String 返回边界要求的名称，多个要求以 "|" 连接
+func (b Boundary) String() string {
+	if b == BoundaryNone {
+		return "BoundaryNone"
+	}
+	var names []string
+	for _, item := range []struct {
+		flag Boundary
+		name string
+	}{
+		{BoundaryWordStart, "BoundaryWordStart"},
+		{BoundaryWordEnd, "BoundaryWordEnd"},
+		{BoundaryLineStart, "BoundaryLineStart"},
+		{BoundaryLineEnd, "BoundaryLineEnd"},
+		{BoundaryTextStart, "BoundaryTextStart"},
+		{BoundaryTextEnd, "BoundaryTextEnd"},
+	} {
+		if b&item.flag != 0 {
+			names = append(names, item.name)
+			b &^= item.flag
+		}
+	}
+	if b != 0 {
+		names = append(names, "Boundary(?)")
+	}
+	return strings.Join(names, "|")
+}
+
+// WithBoundary 为所有模式串设置相同的边界要求，默认为 BoundaryNone
+// Trie、ACTree 和 AC 支持该配置
+func WithBoundary(b Boundary) Option {
+	return WithPatternBoundary(func(int, string) Boundary {
+		return b
+	})
+}
+
+// WithPatternBoundary 按模式串设置边界要求，fn 根据模式串下标和模式串返回该模式串的边界要求
+// 构建和插入模式串时调用fn，拼音形式使用原始模式串的边界要求。Trie、ACTree 和 AC 支持该配置
+func WithPatternBoundary(fn func(index int, pattern string) Boundary) Option {
+	return func(o *options) {
+		o.boundary = fn
+	}
+}
+
+// generic 判断是否需要使用通用扫描路径：启用了字符变换时逐个读取变换后的字符，启用了边界要求时逐个检查匹配
+func (o *options) generic() bool {
+	return o.transformed() || o.boundary != nil
+}
+
+// edgeClass 字符在边界判断中的类别
+type edgeClass uint8
+
+const (
+	edgeText    edgeClass = iota // 文本开头之前或结尾之后
+	edgeNewline                  // 换行符（\n 或 \r）
+	edgeWord                     // 单词字符
+	edgeOther                    // 其他字符
+)
+
+// classify 返回字符的边界类别
+func classify(r rune) edgeClass {
+	switch {
+	case r == '\n' || r == '\r':
+		return edgeNewline
+	case r < utf8.RuneSelf:
+		if 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '_' {
+			return edgeWord
+		}
+		return edgeOther
+	case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Thai, unicode.Lao, unicode.Khmer, unicode.Myanmar):
+		// 不使用空格分词的文字，每个字符自成一个单词
+		return edgeOther
+	case unicode.In(r, unicode.L, unicode.N, unicode.M, unicode.Pc):
+		return edgeWord
+	}
+	return edgeOther
+}
+
+// acceptStart 判断匹配之前的字符类别为before、匹配的第一个字符类别为first时是否满足起始边界要求
+func (b Boundary) acceptStart(before, first edgeClass) bool {
+	switch {
+	case b&BoundaryTextStart != 0 && before != edgeText:
+		return false
+	case b&BoundaryLineStart != 0 && before != edgeText && before != edgeNewline:
+		return false
+	case b&BoundaryWordStart != 0 && before == edgeWord && first == edgeWord:
+		return false
+	}
+	return true
+}
+
+// acceptEnd 判断匹配的最后一个字符类别为last、匹配之后的字符类别为after时是否满足结束边界要求
+func (b Boundary) acceptEnd(last, after edgeClass) bool {
+	switch {
+	case b&BoundaryTextEnd != 0 && after != edgeText:
+		return false
+	case b&BoundaryLineEnd != 0 && after != edgeText && after != edgeNewline:
+		return false
+	case b&BoundaryWordEnd != 0 && last == edgeWord && after == edgeWord:
+		return false
+	}
+	return true
+}
+
+// check 判断文本中位于[start, end)的匹配是否满足边界要求
+func (b Boundary) check(text string, start, end int) bool {
+	if b == BoundaryNone {
+		return true
+	}
+	before, after := edgeText, edgeText
+	if start > 0 {
+		r, _ := utf8.DecodeLastRuneInString(text[:start])
+		before = classify(r)
+	}
+	if end < len(text) {
+		r, _ := utf8.DecodeRuneInString(text[end:])
+		after = classify(r)
+	}
+	first, _ := utf8.DecodeRuneInString(text[start:end])
+	last, _ := utf8.DecodeLastRuneInString(text[start:end])
+	return b.acceptStart(before, classify(first)) && b.acceptEnd(classify(last), after)
+}
+
+// boundaries 每个模式串的边界要求，下标与 Match.Index 对应，nil表示没有边界要求
+type boundaries []Boundary
+
+// appendBoundaries 按配置追加模式串的边界要求，未设置边界要求时返回nil
+// 模式串下标从len(bs)开始
+func (o *options) appendBoundaries(bs boundaries, patterns ...string) boundaries {
+	if o.boundary == nil {
+		return nil
+	}
+	for _, pattern := range patterns {
+		bs = append(bs, o.boundary(len(bs), pattern))
+	}
+	return bs
+}
+
+// accept 判断匹配是否满足边界要求
+func (bs boundaries) accept(text string, m Match) bool {
+	return bs == nil || bs[m.Index].check(text, m.Start, m.End)
+}
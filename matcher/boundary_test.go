@@ -0,0 +1,256 @@
+package matcher
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// 构建支持边界要求的 Matcher 实现
+func buildBoundaryMatchers(t *testing.T, patterns []string, opts ...Option) map[string]Matcher {
+	matchers := map[string]Matcher{
+		"Trie":   NewTrie(opts...),
+		"ACTree": NewAc(opts...),
+		"AC":     NewAC(opts...),
+	}
+	for name, m := range matchers {
+		if err := m.Build(patterns); err != nil {
+			t.Fatalf("%s Build() error = %v", name, err)
+		}
+	}
+	return matchers
+}
+
+func TestBoundary(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		text     string
+		patterns []string
+		want     []Match
+	}{
+		{
+			name:     "全词匹配",
+			opts:     []Option{WithBoundary(BoundaryWord)},
+			text:     "test testing contest test.",
+			patterns: []string{"test"},
+			want: []Match{
+				{Index: 0, Pattern: "test", Start: 0, End: 4, RuneStart: 0, RuneEnd: 4},
+				{Index: 0, Pattern: "test", Start: 21, End: 25, RuneStart: 21, RuneEnd: 25},
+			},
+		},
+		{
+			name:     "中英混合",
+			opts:     []Option{WithBoundary(BoundaryWord)},
+			text:     "中test文 测试test用例 tests",
+			patterns: []string{"test", "测试"},
+			want: []Match{
+				{Index: 0, Pattern: "test", Start: 3, End: 7, RuneStart: 1, RuneEnd: 5},
+				{Index: 1, Pattern: "测试", Start: 11, End: 17, RuneStart: 7, RuneEnd: 9},
+				{Index: 0, Pattern: "test", Start: 17, End: 21, RuneStart: 9, RuneEnd: 13},
+			},
+		},
+		{
+			name: "按模式串设置",
+			opts: []Option{WithPatternBoundary(func(index int, pattern string) Boundary {
+				if pattern == "go" {
+					return BoundaryWord
+				}
+				return BoundaryNone
+			})},
+			text:     "golang go gopher",
+			patterns: []string{"go", "lang"},
+			want: []Match{
+				{Index: 1, Pattern: "lang", Start: 2, End: 6, RuneStart: 2, RuneEnd: 6},
+				{Index: 0, Pattern: "go", Start: 7, End: 9, RuneStart: 7, RuneEnd: 9},
+			},
+		},
+		{
+			name:     "单词开头",
+			opts:     []Option{WithBoundary(BoundaryWordStart)},
+			text:     "unhappy happyness",
+			patterns: []string{"happy"},
+			want: []Match{
+				{Index: 0, Pattern: "happy", Start: 8, End: 13, RuneStart: 8, RuneEnd: 13},
+			},
+		},
+		{
+			name:     "行首行尾",
+			opts:     []Option{WithBoundary(BoundaryLine)},
+			text:     "ok\nnot ok\r\nok",
+			patterns: []string{"ok"},
+			want: []Match{
+				{Index: 0, Pattern: "ok", Start: 0, End: 2, RuneStart: 0, RuneEnd: 2},
+				{Index: 0, Pattern: "ok", Start: 11, End: 13, RuneStart: 11, RuneEnd: 13},
+			},
+		},
+		{
+			name:     "文本开头结尾",
+			opts:     []Option{WithBoundary(BoundaryTextStart)},
+			text:     "abab",
+			patterns: []string{"ab", "b"},
+			want: []Match{
+				{Index: 0, Pattern: "ab", Start: 0, End: 2, RuneStart: 0, RuneEnd: 2},
+			},
+		},
+		{
+			name:     "大小写折叠",
+			opts:     []Option{WithBoundary(BoundaryWord), WithCaseFold(SimpleCaseFold)},
+			text:     "TEST Testing",
+			patterns: []string{"test"},
+			want: []Match{
+				{Index: 0, Pattern: "test", Start: 0, End: 4, RuneStart: 0, RuneEnd: 4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, m := range buildBoundaryMatchers(t, tt.patterns, tt.opts...) {
+				if got := m.FindAll(tt.text); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("%s FindAll() = %+v, want %+v", name, got, tt.want)
+				}
+				if got, ok := m.FindFirst(tt.text); !ok || got != tt.want[0] {
+					t.Errorf("%s FindFirst() = %+v, %v, want %+v", name, got, ok, tt.want[0])
+				}
+			}
+		})
+	}
+}
+
+// 测试各实现的边界匹配与参考实现一致，包括流式扫描
+func TestBoundaryConsistency(t *testing.T) {
+	patterns := []string{"test", "es", "中文", "文", "a b", "ß", "行"}
+	texts := []string{
+		"test tests contest\ntest",
+		"中文test中文 a b ab a  b",
+		"STRAßE ß ss\r\nß",
+		"行\n行行 x行",
+		"",
+	}
+	bound := func(index int, pattern string) Boundary {
+		return []Boundary{BoundaryWord, BoundaryWordEnd, BoundaryWord, BoundaryLineStart, BoundaryWord, BoundaryWord, BoundaryLine}[index]
+	}
+	for _, opts := range [][]Option{
+		{WithPatternBoundary(bound)},
+		{WithPatternBoundary(bound), WithCaseFold(FullCaseFold), WithNormalization(NormalizeSpace)},
+		{WithPatternBoundary(bound), WithMatchKind(LeftmostLongest)},
+	} {
+		o := newOptions(opts)
+		bounds := o.appendBoundaries(nil, patterns...)
+		matchers := buildBoundaryMatchers(t, patterns, opts...)
+		for _, text := range texts {
+			var want []Match
+			for _, m := range foldReference(patterns, text, opts...) {
+				if bounds[m.Index].check(text, m.Start, m.End) {
+					want = append(want, m)
+				}
+			}
+			var wantFirst Match
+			found := false
+			for _, m := range want {
+				if better(m, wantFirst, found) {
+					wantFirst, found = m, true
+				}
+			}
+			want = resolveMatches(want, o.kind)
+			for name, m := range matchers {
+				if got := m.FindAll(text); !reflect.DeepEqual(got, want) {
+					t.Errorf("%s FindAll(%q) = %+v, want %+v", name, text, got, want)
+				}
+				if o.kind == MatchAll {
+					if got, ok := m.FindFirst(text); ok != found || got != wantFirst {
+						t.Errorf("%s FindFirst(%q) = %+v, %v, want %+v, %v", name, text, got, ok, wantFirst, found)
+					}
+				}
+				if got := m.Contains(text); got != found {
+					t.Errorf("%s Contains(%q) = %v, want %v", name, text, got, found)
+				}
+			}
+			if o.kind != MatchAll {
+				continue
+			}
+
+			// 流式扫描逐字节写入
+			var got []Match
+			s := matchers["AC"].(*AC).NewStream(func(m Match) bool {
+				got = append(got, m)
+				return true
+			})
+			for i := 0; i < len(text); i++ {
+				s.Write([]byte{text[i]})
+			}
+			s.Flush()
+			sortMatches(got)
+			if !slices.Equal(got, want) {
+				t.Errorf("Stream(%q) = %+v, want %+v", text, got, want)
+			}
+		}
+	}
+}
+
+func TestBoundaryInsert(t *testing.T) {
+	trie := NewTrie(WithBoundary(BoundaryWord))
+	trie.Insert("go")
+	if got := trie.SearchList("golang go"); !slices.Equal(got, []string{"go"}) {
+		t.Errorf("SearchList() = %v", got)
+	}
+	if got := trie.Search("golang go"); !reflect.DeepEqual(got, map[string][]int{"go": {7}}) {
+		t.Errorf("Search() = %v", got)
+	}
+
+	ac := NewAC(WithBoundary(BoundaryWord))
+	if err := ac.Insert("go"); err != nil {
+		t.Fatal(err)
+	}
+	ac.BuildFail()
+	if got := ac.Search("golang go"); !reflect.DeepEqual(got, map[string][]int{"go": {7}}) {
+		t.Errorf("Search() = %v", got)
+	}
+	if got := NewAc(WithBoundary(BoundaryWord)); got.Build([]string{"go"}) != nil || !slices.Equal(got.Scan("golang go"), []string{"go"}) {
+		t.Errorf("Scan() = %v", got.Scan("golang go"))
+	}
+}
+
+// 测试不支持边界要求的匹配器构建时返回错误
+func TestBoundaryUnsupported(t *testing.T) {
+	for _, opt := range []Option{
+		WithBoundary(BoundaryWord),
+		WithPatternBoundary(func(int, string) Boundary { return BoundaryLine }),
+	} {
+		for name, m := range map[string]Matcher{"BruteForce": NewBruteForce(opt), "KMP": NewKMP(opt)} {
+			if err := m.Build([]string{"go"}); !errors.Is(err, ErrUnsupportedOption) {
+				t.Errorf("%s Build() error = %v, want ErrUnsupportedOption", name, err)
+			}
+			if m.Contains("golang go") {
+				t.Errorf("%s Contains() = true after failed Build", name)
+			}
+		}
+	}
+}
+
+func TestBoundaryString(t *testing.T) {
+	for b, want := range map[Boundary]string{
+		BoundaryNone:                        "BoundaryNone",
+		BoundaryWord:                        "BoundaryWordStart|BoundaryWordEnd",
+		BoundaryLineEnd | BoundaryTextStart: "BoundaryLineEnd|BoundaryTextStart",
+		Boundary(1 << 7):                    "Boundary(?)",
+	} {
+		if got := b.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestClassify(t *testing.T) {
+	for r, want := range map[rune]edgeClass{
+		'a': edgeWord, 'Z': edgeWord, '9': edgeWord, '_': edgeWord, 'é': edgeWord, 'ж': edgeWord, '٣': edgeWord,
+		'中': edgeOther, 'あ': edgeOther, ' ': edgeOther, '，': edgeOther, '-': edgeOther,
+		'\n': edgeNewline, '\r': edgeNewline,
+	} {
+		if got := classify(r); got != want {
+			t.Errorf("classify(%q) = %d, want %d", r, got, want)
+		}
+	}
+}
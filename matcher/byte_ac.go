@@ -0,0 +1,260 @@
+package matcher
+
+import (
+	"iter"
+	"slices"
+	"unicode/utf8"
+)
+
+// ByteAC 基于UTF-8字节构建的AC自动机
+//
+// 与 AC 和 ACTree 按rune构建不同，ByteAC 直接在模式串的UTF-8字节上构建自动机，
+// 搜索时逐字节扫描原始字符串，不需要把文本转换为[]rune。
+// 所有状态以扁平数组存储：根节点使用256项的直接跳转表，其他节点的转移按字节升序
+// 连续存放在 labels/targets 中。
+type ByteAC struct {
+	patterns []string // 模式串集合，下标与 Match.Index 对应
+	lengths  []int    // 模式串长度缓存（按rune计算）
+
+	root      [256]int32 // 根节点的跳转表，0表示回到根节点
+	edgeStart []int32    // 每个状态的转移在 labels/targets 中的起始位置，长度为状态数+1
+	labels    []byte     // 转移字节
+	targets   []int32    // 转移目标状态
+	fail      []int32    // 失败指针
+	outStart  []int32    // 每个状态的输出在 outputs 中的起始位置，长度为状态数+1
+	outputs   []int32    // 输出集合（模式串下标），同一状态内按模式串长度升序
+	opts      options    // 构建配置
+}
+
+// byteTrieNode 构建 ByteAC 时使用的临时节点
+type byteTrieNode struct {
+	labels  []byte  // 转移字节（升序）
+	targets []int32 // 转移目标状态
+	index   int32   // 模式串下标，-1表示不是模式串结尾
+}
+
+// NewByteAC 创建新的字节AC自动机
+// 只支持 WithMatchKind，传入大小写折叠、规范化、等价字符、可忽略字符、边界要求或拼音配置时
+// Build 返回 ErrUnsupportedOption
+func NewByteAC(opts ...Option) *ByteAC {
+	// 先使用默认配置构建空的自动机，配置不受支持时 Build 失败也不会留下未初始化的状态
+	ac := &ByteAC{}
+	ac.Build(nil)
+	ac.opts = newOptions(opts)
+	return ac
+}
+
+// BuildByteAC 预处理构建字节AC自动机
+func BuildByteAC(patterns []string, opts ...Option) (*ByteAC, error) {
+	ac := NewByteAC(opts...)
+	if err := ac.Build(patterns); err != nil {
+		return nil, err
+	}
+	return ac, nil
+}
+
+// Build 使用模式串集合构建字节AC自动机，会丢弃之前构建的内容
+func (ac *ByteAC) Build(patterns []string) error {
+	if err := ac.opts.onlyMatchKind("ByteAC"); err != nil {
+		return err
+	}
+	ac.patterns = slices.Clone(patterns)
+	ac.lengths = make([]int, len(patterns))
+
+	// 构建临时Trie树，状态0为根节点
+	nodes := []byteTrieNode{{index: -1}}
+	for i, pattern := range patterns {
+		ac.lengths[i] = utf8.RuneCountInString(pattern)
+		if pattern == "" {
+			continue
+		}
+		state := int32(0)
+		for j := 0; j < len(pattern); j++ {
+			b := pattern[j]
+			n := &nodes[state]
+			pos, found := slices.BinarySearch(n.labels, b)
+			if found {
+				state = n.targets[pos]
+				continue
+			}
+			next := int32(len(nodes))
+			n.labels = slices.Insert(n.labels, pos, b)
+			n.targets = slices.Insert(n.targets, pos, next)
+			nodes = append(nodes, byteTrieNode{index: -1})
+			state = next
+		}
+		// 重复的模式串保留第一次出现的下标
+		if nodes[state].index < 0 {
+			nodes[state].index = int32(i)
+		}
+	}
+
+	ac.flatten(nodes)
+	ac.buildFail(nodes)
+	return nil
+}
+
+// flatten 将临时Trie树的转移压缩到扁平数组中
+func (ac *ByteAC) flatten(nodes []byteTrieNode) {
+	ac.root = [256]int32{}
+	ac.edgeStart = make([]int32, len(nodes)+1)
+	ac.labels = ac.labels[:0]
+	ac.targets = ac.targets[:0]
+	for i, n := range nodes {
+		ac.edgeStart[i] = int32(len(ac.labels))
+		ac.labels = append(ac.labels, n.labels...)
+		ac.targets = append(ac.targets, n.targets...)
+	}
+	ac.edgeStart[len(nodes)] = int32(len(ac.labels))
+	for i, b := range nodes[0].labels {
+		ac.root[b] = nodes[0].targets[i]
+	}
+}
+
+// buildFail 按层次遍历构建失败指针和输出集合
+func (ac *ByteAC) buildFail(nodes []byteTrieNode) {
+	ac.fail = make([]int32, len(nodes))
+	output := make([][]int32, len(nodes))
+
+	// 使用切片替代通用队列，提高性能
+	queue := make([]int32, 0, 256)
+	queue = append(queue, 0)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for i, b := range nodes[current].labels {
+			child := nodes[current].targets[i]
+			queue = append(queue, child)
+			if current != 0 {
+				ac.fail[child] = ac.goTo(ac.fail[current], b)
+			}
+			// 失败指针指向的状态深度更小，其输出集合已经构建完成
+			output[child] = append(output[child], output[ac.fail[child]]...)
+			if nodes[child].index >= 0 {
+				output[child] = append(output[child], nodes[child].index)
+			}
+		}
+	}
+
+	// 按状态编号顺序压缩输出集合
+	ac.outStart = make([]int32, len(nodes)+1)
+	ac.outputs = ac.outputs[:0]
+	for state := range nodes {
+		ac.outStart[state] = int32(len(ac.outputs))
+		ac.outputs = append(ac.outputs, output[state]...)
+	}
+	ac.outStart[len(nodes)] = int32(len(ac.outputs))
+}
+
+// goTo 从state出发沿失败指针查找字节b的转移，最终回到根节点时返回根节点的跳转
+func (ac *ByteAC) goTo(state int32, b byte) int32 {
+	for state != 0 {
+		if next, ok := ac.edge(state, b); ok {
+			return next
+		}
+		state = ac.fail[state]
+	}
+	return ac.root[b]
+}
+
+// edge 查找状态在字节b上的直接转移
+func (ac *ByteAC) edge(state int32, b byte) (int32, bool) {
+	start, end := ac.edgeStart[state], ac.edgeStart[state+1]
+	for i := start; i < end; i++ {
+		if ac.labels[i] == b {
+			return ac.targets[i], true
+		}
+		if ac.labels[i] > b {
+			break
+		}
+	}
+	return 0, false
+}
+
+// match 根据模式串下标和结束位置构造匹配结果
+func (ac *ByteAC) match(index int32, end, runeEnd int) Match {
+	return Match{
+		Index:     int(index),
+		Pattern:   ac.patterns[index],
+		Start:     end - len(ac.patterns[index]),
+		End:       end,
+		RuneStart: runeEnd - ac.lengths[index],
+		RuneEnd:   runeEnd,
+	}
+}
+
+// Each 按扫描顺序（结束位置升序，结束位置相同时短的在前）对每个匹配调用fn，
+// fn返回false时停止扫描。扫描过程不分配内存。总是报告所有重叠的匹配，不受匹配语义影响。
+//
+// rune偏移按非UTF-8后续字节计数，对于合法的UTF-8文本与按rune遍历的结果一致。
+func (ac *ByteAC) Each(text string, fn func(Match) bool) {
+	state := int32(0)
+	runeEnd := 0
+	for i := 0; i < len(text); i++ {
+		b := text[i]
+		if b&0xC0 != 0x80 {
+			runeEnd++
+		}
+		state = ac.goTo(state, b)
+		for _, index := range ac.outputs[ac.outStart[state]:ac.outStart[state+1]] {
+			if !fn(ac.match(index, i+1, runeEnd)) {
+				return
+			}
+		}
+	}
+}
+
+// Matches 返回按扫描顺序产生所有重叠匹配的迭代器，同 Each
+func (ac *ByteAC) Matches(text string) iter.Seq[Match] {
+	return func(yield func(Match) bool) {
+		ac.Each(text, yield)
+	}
+}
+
+// FindFirst 返回文本中最先出现的匹配
+func (ac *ByteAC) FindFirst(text string) (Match, bool) {
+	if ac.opts.kind != MatchAll {
+		// 不重叠语义下最先的匹配即结果中的第一个
+		if matches := ac.FindAll(text); len(matches) > 0 {
+			return matches[0], true
+		}
+		return Match{}, false
+	}
+	state := int32(0)
+	runeEnd := 0
+	for i := 0; i < len(text); i++ {
+		b := text[i]
+		if b&0xC0 != 0x80 {
+			runeEnd++
+		}
+		state = ac.goTo(state, b)
+		if start, end := ac.outStart[state], ac.outStart[state+1]; start < end {
+			// 输出集合中最后一个模式串最长
+			return ac.match(ac.outputs[end-1], i+1, runeEnd), true
+		}
+	}
+	return Match{}, false
+}
+
+// FindAll 按匹配语义返回文本中的匹配
+func (ac *ByteAC) FindAll(text string) []Match {
+	var result []Match
+	ac.Each(text, func(m Match) bool {
+		result = append(result, m)
+		return true
+	})
+	return resolveMatches(result, ac.opts.kind)
+}
+
+// Contains 判断文本中是否包含任一模式串
+func (ac *ByteAC) Contains(text string) bool {
+	state := int32(0)
+	for i := 0; i < len(text); i++ {
+		state = ac.goTo(state, text[i])
+		if ac.outStart[state] < ac.outStart[state+1] {
+			return true
+		}
+	}
+	return false
+}
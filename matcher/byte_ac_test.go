@@ -0,0 +1,99 @@
+package matcher
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// 测试字节AC自动机与Trie树结果一致
+func TestByteACConsistency(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		patterns []string
+	}{
+		{
+			name:     "基本匹配",
+			text:     "你好，世界！Hello, World!",
+			patterns: []string{"你好", "世界", "Hello", "World"},
+		},
+		{
+			name:     "重叠模式",
+			text:     "测试测试测试",
+			patterns: []string{"测试", "测试测", "测试测试", "试"},
+		},
+		{
+			name:     "特殊字符",
+			text:     "你好👋世界🌍",
+			patterns: []string{"👋世界", "世界🌍", "你好👋"},
+		},
+		{
+			name:     "共享UTF-8前缀字节",
+			text:     "一丁七万丈三上下",
+			patterns: []string{"丁七", "万", "三上下", "七万丈"},
+		},
+		{
+			name:     "外来字符",
+			text:     "xb yb ab zab 世界乙界",
+			patterns: []string{"ab", "界"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ac, err := BuildByteAC(tc.patterns)
+			if err != nil {
+				t.Fatalf("BuildByteAC() error = %v", err)
+			}
+			got := ac.FindAll(tc.text)
+			want := BuildTrie(tc.patterns).FindAll(tc.text)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ByteAC.FindAll() = %+v, Trie.FindAll() = %+v", got, want)
+			}
+		})
+	}
+}
+
+// 测试 Each 扫描过程不分配内存
+func TestByteACEachAllocs(t *testing.T) {
+	ac, _ := BuildByteAC([]string{"你好", "世界", "hello", "world"})
+	text := generateRepeatedText("你好hello世界world", 10)
+	count := 0
+	allocs := testing.AllocsPerRun(100, func() {
+		ac.Each(text, func(m Match) bool {
+			count++
+			return true
+		})
+	})
+	if allocs != 0 {
+		t.Errorf("Each() allocs = %v, want 0", allocs)
+	}
+	if count == 0 {
+		t.Errorf("Each() found no matches")
+	}
+}
+
+// unsupportedOptions ByteAC 和 DoubleArray 不支持的配置
+var unsupportedOptions = []Option{
+	WithCaseFold(SimpleCaseFold),
+	WithNormalization(NormalizeAll),
+	WithIgnorable(IsNoise, 1),
+	WithBoundary(BoundaryWord),
+	WithPinyin(PinyinFull, nil),
+}
+
+// 测试不支持的配置在构建时返回错误，并保留构建前的空自动机
+func TestByteACUnsupportedOption(t *testing.T) {
+	for _, opt := range unsupportedOptions {
+		if _, err := BuildByteAC([]string{"ab"}, opt); !errors.Is(err, ErrUnsupportedOption) {
+			t.Errorf("BuildByteAC() error = %v", err)
+		}
+		if ac := NewByteAC(opt); ac.Build([]string{"ab"}) == nil || ac.Contains("ab") {
+			t.Error("Build() should fail and keep the empty automaton")
+		}
+	}
+	if _, err := BuildByteAC([]string{"ab"}, WithMatchKind(LeftmostLongest)); err != nil {
+		t.Errorf("BuildByteAC() error = %v", err)
+	}
+}
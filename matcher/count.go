@@ -0,0 +1,95 @@
+package matcher
+
+// FirstMatch 返回扫描过程中找到的第一个匹配，找到后立即停止扫描
+//
+// 与 FindFirst 不同，FirstMatch 不受匹配语义影响，也不保证返回结束位置相同的匹配中最长的一个，
+// 适用于只需要知道是否命中以及命中了哪个模式串的场景。
+func (ac *AC) FirstMatch(text string) (first Match, found bool) {
+	ac.Each(text, func(m Match) bool {
+		first, found = m, true
+		return false
+	})
+	return first, found
+}
+
+// ContainsAny 判断文本中是否包含任一模式串，是 Contains 的简单包装，找到第一个匹配后立即停止扫描
+func (ac *AC) ContainsAny(text string) bool {
+	return ac.Contains(text)
+}
+
+// CountMatches 按匹配语义统计每个模式串的匹配次数，不保存匹配位置
+// 匹配语义为 MatchAll 时扫描过程只为结果分配内存
+func (ac *AC) CountMatches(text string) map[string]int {
+	counts := make(map[string]int)
+	if ac.opts.kind != MatchAll {
+		for _, m := range ac.FindAll(text) {
+			counts[m.Pattern]++
+		}
+		return counts
+	}
+	ac.Each(text, func(m Match) bool {
+		counts[m.Pattern]++
+		return true
+	})
+	return counts
+}
+
+// FirstMatch 返回扫描过程中找到的第一个匹配，找到后立即停止扫描，见 AC.FirstMatch
+func (a *ACTree) FirstMatch(text string) (first Match, found bool) {
+	a.Each(text, func(m Match) bool {
+		first, found = m, true
+		return false
+	})
+	return first, found
+}
+
+// ContainsAny 判断文本中是否包含任一词，是 Contains 的简单包装，找到第一个匹配后立即停止扫描
+func (a *ACTree) ContainsAny(text string) bool {
+	return a.Contains(text)
+}
+
+// CountMatches 按匹配语义统计每个词的匹配次数，不保存匹配位置
+func (a *ACTree) CountMatches(text string) map[string]int {
+	counts := make(map[string]int)
+	if a.opts.kind != MatchAll {
+		for _, m := range a.FindAll(text) {
+			counts[m.Pattern]++
+		}
+		return counts
+	}
+	a.Each(text, func(m Match) bool {
+		counts[m.Pattern]++
+		return true
+	})
+	return counts
+}
+
+// FirstMatch 返回起始位置最靠前的匹配中最短的一个，找到后立即停止匹配
+func (t *Trie) FirstMatch(text string) (first Match, found bool) {
+	t.Each(text, func(m Match) bool {
+		first, found = m, true
+		return false
+	})
+	return first, found
+}
+
+// ContainsAny 判断文本中是否包含任一模式串，是 Contains 的简单包装，找到第一个匹配后立即停止匹配
+func (t *Trie) ContainsAny(text string) bool {
+	return t.Contains(text)
+}
+
+// CountMatches 按匹配语义统计每个模式串的匹配次数，不保存匹配位置
+func (t *Trie) CountMatches(text string) map[string]int {
+	counts := make(map[string]int)
+	if t.opts.kind != MatchAll {
+		for _, m := range t.FindAll(text) {
+			counts[m.Pattern]++
+		}
+		return counts
+	}
+	t.Each(text, func(m Match) bool {
+		counts[m.Pattern]++
+		return true
+	})
+	return counts
+}
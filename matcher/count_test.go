@@ -0,0 +1,68 @@
+package matcher
+
+import (
+	"reflect"
+	"testing"
+)
+
+// countMatcher 支持快速检查和计数的 Matcher 实现
+type countMatcher interface {
+	Matcher
+	FirstMatch(text string) (Match, bool)
+	ContainsAny(text string) bool
+	CountMatches(text string) map[string]int
+}
+
+func TestCountMatches(t *testing.T) {
+	patterns := []string{"测试", "测试测", "试测", "he", "hello", "he"}
+	texts := []string{"测试测试测 hello HELLO 测试", "", "没有匹配"}
+	for _, opts := range [][]Option{
+		nil,
+		{WithMatchKind(LeftmostLongest)},
+		{WithCaseFold(SimpleCaseFold), WithBoundary(BoundaryWord)},
+	} {
+		ac, _ := BuildAC(patterns, opts...)
+		tree := NewAc(opts...)
+		tree.Build(patterns)
+		for _, m := range []countMatcher{ac, tree, BuildTrie(patterns, opts...)} {
+			for _, text := range texts {
+				matches := m.FindAll(text)
+				want := make(map[string]int)
+				for _, match := range matches {
+					want[match.Pattern]++
+				}
+				if got := m.CountMatches(text); !reflect.DeepEqual(got, want) {
+					t.Errorf("%T CountMatches(%q) = %v, want %v", m, text, got, want)
+				}
+				if got := m.ContainsAny(text); got != (len(matches) > 0) {
+					t.Errorf("%T ContainsAny(%q) = %v", m, text, got)
+				}
+				first, found := m.FirstMatch(text)
+				if found != (len(matches) > 0) || (found && m.FindAll(text[first.Start:first.End]) == nil) {
+					t.Errorf("%T FirstMatch(%q) = %+v, %v", m, text, first, found)
+				}
+			}
+		}
+	}
+}
+
+// 找到第一个匹配后不再读取之后的文本
+func TestFirstMatchEarlyExit(t *testing.T) {
+	calls := 0
+	opts := []Option{WithIgnorable(func(r rune) bool {
+		calls++
+		return r == '*'
+	}, 1)}
+	patterns := []string{"违禁", "禁词"}
+	text := "一个违禁词" + string(make([]rune, 1000))
+	ac, _ := BuildAC(patterns, opts...)
+	tree := NewAc(opts...)
+	tree.Build(patterns)
+	for _, m := range []countMatcher{ac, tree, BuildTrie(patterns, opts...)} {
+		calls = 0
+		first, found := m.FirstMatch(text)
+		if !found || first.Pattern != "违禁" || calls > 10 {
+			t.Errorf("%T FirstMatch() = %+v, %v after %d calls", m, first, found, calls)
+		}
+	}
+}
@@ -0,0 +1,414 @@
+package matcher
+
+import (
+	"slices"
+	"unicode/utf8"
+)
+
+// DoubleArray 双数组（base/check）实现的Trie树和AC自动机
+//
+// 状态s在字符编码c上的转移为 t = base[s] + c，当且仅当 check[t] == s 时转移存在。
+// 与 TrieNode 和 node 使用的 map 相比，所有状态都存放在连续的整型数组中，
+// 适合十万级以上的大词典。既支持 Trie 的 Search/SearchList，也支持 ACTree 的 Scan。
+type DoubleArray struct {
+	patterns []string // 模式串集合，下标与 Match.Index 对应
+	lengths  []int    // 模式串长度缓存（按rune计算）
+
+	charMap  map[rune]int32 // 字符到编码的映射，编码从1开始
+	base     []int32        // 状态的转移基址
+	check    []int32        // 状态的父状态，-1表示空闲
+	index    []int32        // 状态对应的模式串下标，-1表示不是模式串结尾
+	fail     []int32        // 失败指针
+	outStart []int32        // 每个状态的输出在 outputs 中的起始位置，长度为状态数+1
+	outputs  []int32        // 输出集合（模式串下标），同一状态内按模式串长度升序
+	opts     options        // 构建配置
+}
+
+// daBuildNode 构建双数组时使用的临时节点
+type daBuildNode struct {
+	codes    []int32 // 子节点的字符编码（升序）
+	children []int32 // 子节点在临时节点列表中的位置
+	index    int32   // 模式串下标，-1表示不是模式串结尾
+}
+
+// NewDoubleArray 创建新的双数组Trie树
+// 只支持 WithMatchKind，传入大小写折叠、规范化、等价字符、可忽略字符、边界要求或拼音配置时
+// Build 返回 ErrUnsupportedOption
+func NewDoubleArray(opts ...Option) *DoubleArray {
+	// 先使用默认配置构建空的双数组，配置不受支持时 Build 失败也不会留下未初始化的状态
+	da := &DoubleArray{}
+	da.Build(nil)
+	da.opts = newOptions(opts)
+	return da
+}
+
+// BuildDoubleArray 预处理构建双数组Trie树
+func BuildDoubleArray(patterns []string, opts ...Option) (*DoubleArray, error) {
+	da := NewDoubleArray(opts...)
+	if err := da.Build(patterns); err != nil {
+		return nil, err
+	}
+	return da, nil
+}
+
+// Build 使用模式串集合构建双数组，会丢弃之前构建的内容
+func (da *DoubleArray) Build(patterns []string) error {
+	if err := da.opts.onlyMatchKind("DoubleArray"); err != nil {
+		return err
+	}
+	da.patterns = slices.Clone(patterns)
+	da.lengths = make([]int, len(patterns))
+	da.buildCharMap(patterns)
+
+	// 构建临时Trie树
+	nodes := []daBuildNode{{index: -1}}
+	for i, pattern := range patterns {
+		da.lengths[i] = utf8.RuneCountInString(pattern)
+		if pattern == "" {
+			continue
+		}
+		current := int32(0)
+		for _, r := range pattern {
+			code := da.charMap[r]
+			n := &nodes[current]
+			pos, found := slices.BinarySearch(n.codes, code)
+			if found {
+				current = n.children[pos]
+				continue
+			}
+			next := int32(len(nodes))
+			n.codes = slices.Insert(n.codes, pos, code)
+			n.children = slices.Insert(n.children, pos, next)
+			nodes = append(nodes, daBuildNode{index: -1})
+			current = next
+		}
+		// 重复的模式串保留第一次出现的下标
+		if nodes[current].index < 0 {
+			nodes[current].index = int32(i)
+		}
+	}
+
+	position := da.place(nodes)
+	da.buildFail(nodes, position)
+	return nil
+}
+
+// buildCharMap 构建字符映射，字符按码点升序从1开始分配编码
+func (da *DoubleArray) buildCharMap(patterns []string) {
+	charSet := make(map[rune]bool)
+	for _, pattern := range patterns {
+		for _, r := range pattern {
+			charSet[r] = true
+		}
+	}
+	chars := make([]rune, 0, len(charSet))
+	for r := range charSet {
+		chars = append(chars, r)
+	}
+	slices.Sort(chars)
+	da.charMap = make(map[rune]int32, len(chars))
+	for i, r := range chars {
+		da.charMap[r] = int32(i + 1)
+	}
+}
+
+// daMaxTries 一次查找中尝试的空闲位置超过该数量时，后续查找不再从这些位置开始
+const daMaxTries = 32
+
+// daBuilder 构建双数组时维护空闲位置的双向循环链表，0号位置（根节点）作为哨兵
+type daBuilder struct {
+	da         *DoubleArray
+	next, prev []int32 // 空闲位置链表
+	start      int32   // 查找base时的起始空闲位置，0表示从链表头开始
+}
+
+// place 按层次遍历把临时节点放入双数组，为每个节点寻找不冲突的base
+// 返回每个临时节点在双数组中的状态
+func (da *DoubleArray) place(nodes []daBuildNode) []int32 {
+	size := len(nodes) + len(da.charMap) + 1
+	da.base = make([]int32, 1, size)
+	da.check = make([]int32, 1, size)
+	da.index = make([]int32, 1, size)
+	da.check[0] = -1
+	da.index[0] = -1
+	b := &daBuilder{da: da, next: make([]int32, 1, size), prev: make([]int32, 1, size)}
+
+	position := make([]int32, len(nodes))
+	// 使用切片替代通用队列，提高性能
+	queue := make([]int32, 0, 256)
+	queue = append(queue, 0)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		n := nodes[current]
+		state := position[current]
+		da.index[state] = n.index
+		if len(n.codes) == 0 {
+			continue
+		}
+
+		base := b.findBase(n.codes)
+		da.base[state] = base
+		for i, code := range n.codes {
+			t := base + code
+			b.grow(t + 1)
+			b.use(t, state)
+			position[n.children[i]] = t
+			queue = append(queue, n.children[i])
+		}
+	}
+	return position
+}
+
+// findBase 让第一个子节点依次尝试落在每个空闲位置上，找到能容纳所有子节点的base
+// 已经被多次尝试都无法使用的空闲位置会被跳过，以少量空间换取构建速度
+func (b *daBuilder) findBase(codes []int32) int32 {
+	p := b.start
+	if p == 0 {
+		p = b.next[0]
+	}
+	for tries := 0; p != 0; p = b.next[p] {
+		if p <= codes[0] {
+			continue
+		}
+		if tries++; tries > daMaxTries {
+			b.start = p
+		}
+		if base := p - codes[0]; b.fits(base, codes[1:]) {
+			return base
+		}
+	}
+	// 数组末尾之后的位置全部空闲
+	return max(int32(len(b.da.check)), codes[0]+1) - codes[0]
+}
+
+// fits 判断以base为基址时所有子节点的位置是否空闲
+func (b *daBuilder) fits(base int32, codes []int32) bool {
+	check := b.da.check
+	for _, code := range codes {
+		t := base + code
+		if t < int32(len(check)) && check[t] >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// grow 扩展数组使其长度至少为n，新位置标记为空闲并加入空闲链表尾部
+func (b *daBuilder) grow(n int32) {
+	da := b.da
+	for int32(len(da.check)) < n {
+		t := int32(len(da.check))
+		da.base = append(da.base, 0)
+		da.check = append(da.check, -1)
+		da.index = append(da.index, -1)
+		last := b.prev[0]
+		b.next = append(b.next, 0)
+		b.prev = append(b.prev, last)
+		b.next[last] = t
+		b.prev[0] = t
+	}
+}
+
+// use 占用位置t并从空闲链表中移除
+func (b *daBuilder) use(t, parent int32) {
+	b.da.check[t] = parent
+	if b.start == t {
+		b.start = b.next[t]
+	}
+	b.next[b.prev[t]] = b.next[t]
+	b.prev[b.next[t]] = b.prev[t]
+}
+
+// child 返回状态在字符编码上的直接转移，不存在时返回-1
+func (da *DoubleArray) child(state, code int32) int32 {
+	t := da.base[state] + code
+	if da.base[state] > 0 && t < int32(len(da.check)) && da.check[t] == state {
+		return t
+	}
+	return -1
+}
+
+// buildFail 按层次遍历构建失败指针和输出集合
+// position[i] 为第i个临时节点在双数组中的状态
+func (da *DoubleArray) buildFail(nodes []daBuildNode, position []int32) {
+	n := len(da.check)
+	da.fail = make([]int32, n)
+	output := make([][]int32, n)
+
+	// 使用切片替代通用队列，提高性能
+	queue := make([]int32, 0, 256)
+	queue = append(queue, 0)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		state := position[current]
+		for i, code := range nodes[current].codes {
+			queue = append(queue, nodes[current].children[i])
+			child := position[nodes[current].children[i]]
+			if state != 0 {
+				da.fail[child] = da.nextState(da.fail[state], code)
+			}
+			// 失败指针指向的状态深度更小，其输出集合已经构建完成
+			output[child] = append(output[child], output[da.fail[child]]...)
+			if da.index[child] >= 0 {
+				output[child] = append(output[child], da.index[child])
+			}
+		}
+	}
+
+	da.outStart = make([]int32, n+1)
+	da.outputs = da.outputs[:0]
+	for state := range n {
+		da.outStart[state] = int32(len(da.outputs))
+		da.outputs = append(da.outputs, output[state]...)
+	}
+	da.outStart[n] = int32(len(da.outputs))
+}
+
+// nextState 沿失败指针查找下一个状态，code为0表示字符集之外的字符
+func (da *DoubleArray) nextState(state, code int32) int32 {
+	if code == 0 {
+		return 0
+	}
+	for {
+		if next := da.child(state, code); next >= 0 {
+			return next
+		}
+		if state == 0 {
+			return 0
+		}
+		state = da.fail[state]
+	}
+}
+
+// match 根据模式串下标和结束位置构造匹配结果
+func (da *DoubleArray) match(index int32, end, runeEnd int) Match {
+	return Match{
+		Index:     int(index),
+		Pattern:   da.patterns[index],
+		Start:     end - len(da.patterns[index]),
+		End:       end,
+		RuneStart: runeEnd - da.lengths[index],
+		RuneEnd:   runeEnd,
+	}
+}
+
+// Search 在文本中搜索所有模式串出现的位置（Trie树方式逐个起始位置匹配）
+// 返回一个map，key是模式串，value是该模式串在文本中出现的所有位置的切片
+func (da *DoubleArray) Search(text string) map[string][]int {
+	result := make(map[string][]int)
+	runes := []rune(text)
+	n := len(runes)
+
+	// 对文本中的每个位置进行匹配
+	for i := 0; i < n; i++ {
+		state := int32(0)
+		for j := i; j < n; j++ {
+			if state = da.child(state, da.charMap[runes[j]]); state < 0 {
+				break
+			}
+			if index := da.index[state]; index >= 0 {
+				pattern := da.patterns[index]
+				result[pattern] = append(result[pattern], i)
+			}
+		}
+	}
+	return result
+}
+
+// SearchList 在文本中搜索所有模式串，返回匹配的字符串列表（去重）
+func (da *DoubleArray) SearchList(text string) []string {
+	result := make([]string, 0, 64) // 预分配空间
+	seen := make(map[int32]bool)    // 用于去重
+	runes := []rune(text)
+	n := len(runes)
+
+	// 对文本中的每个位置进行匹配
+	for i := 0; i < n; i++ {
+		state := int32(0)
+		for j := i; j < n; j++ {
+			if state = da.child(state, da.charMap[runes[j]]); state < 0 {
+				break
+			}
+			if index := da.index[state]; index >= 0 && !seen[index] {
+				result = append(result, da.patterns[index])
+				seen[index] = true
+			}
+		}
+	}
+	return result
+}
+
+// Scan 使用失败指针扫描文本，返回所有匹配到的模式串（AC自动机方式）
+// 匹配语义不是 MatchAll 时只返回选中的不重叠匹配
+func (da *DoubleArray) Scan(text string) []string {
+	result := make([]string, 0, 64) // 预分配结果空间
+	if da.opts.kind != MatchAll {
+		for _, m := range da.FindAll(text) {
+			result = append(result, m.Pattern)
+		}
+		return result
+	}
+	state := int32(0)
+	for _, r := range text {
+		state = da.nextState(state, da.charMap[r])
+		for _, index := range da.outputs[da.outStart[state]:da.outStart[state+1]] {
+			result = append(result, da.patterns[index])
+		}
+	}
+	return result
+}
+
+// FindFirst 返回文本中最先出现的匹配
+func (da *DoubleArray) FindFirst(text string) (Match, bool) {
+	if da.opts.kind != MatchAll {
+		// 不重叠语义下最先的匹配即结果中的第一个
+		if matches := da.FindAll(text); len(matches) > 0 {
+			return matches[0], true
+		}
+		return Match{}, false
+	}
+	state := int32(0)
+	runeEnd := 0
+	for end := 0; end < len(text); {
+		r, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+		runeEnd++
+		state = da.nextState(state, da.charMap[r])
+		if start, last := da.outStart[state], da.outStart[state+1]; start < last {
+			// 输出集合中最后一个模式串最长
+			return da.match(da.outputs[last-1], end, runeEnd), true
+		}
+	}
+	return Match{}, false
+}
+
+// FindAll 按匹配语义返回文本中的匹配
+func (da *DoubleArray) FindAll(text string) []Match {
+	var result []Match
+	state := int32(0)
+	runeEnd := 0
+	for end := 0; end < len(text); {
+		r, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+		runeEnd++
+		state = da.nextState(state, da.charMap[r])
+		for _, index := range da.outputs[da.outStart[state]:da.outStart[state+1]] {
+			result = append(result, da.match(index, end, runeEnd))
+		}
+	}
+	return resolveMatches(result, da.opts.kind)
+}
+
+// Contains 判断文本中是否包含任一模式串
+func (da *DoubleArray) Contains(text string) bool {
+	state := int32(0)
+	for _, r := range text {
+		state = da.nextState(state, da.charMap[r])
+		if da.outStart[state] < da.outStart[state+1] {
+			return true
+		}
+	}
+	return false
+}
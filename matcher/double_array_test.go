@@ -0,0 +1,157 @@
+package matcher
+
+import (
+	"errors"
+	"math/rand/v2"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// 生成count个随机中文词，词长2到5个字符
+func generateDictionary(count int) []string {
+	rng := rand.New(rand.NewPCG(1, 2))
+	words := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		word := make([]rune, 2+rng.IntN(4))
+		for j := range word {
+			word[j] = rune(0x4e00 + rng.IntN(3000))
+		}
+		words = append(words, string(word))
+	}
+	return words
+}
+
+// 测试双数组与map实现的Trie树、AC自动机结果一致
+func TestDoubleArrayConsistency(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		patterns []string
+	}{
+		{
+			name:     "经典示例",
+			text:     "ushers",
+			patterns: []string{"he", "she", "his", "hers"},
+		},
+		{
+			name:     "重叠模式",
+			text:     "测试测试测试",
+			patterns: []string{"测试", "测试测", "测试测试", "试"},
+		},
+		{
+			name:     "特殊字符与外来字符",
+			text:     "😀👋🌍你👋世界🌍🙂世🌍",
+			patterns: []string{"👋世界", "世界🌍", "你好👋"},
+		},
+		{
+			name:     "大词典",
+			text:     string(slices.Concat([]rune(generateRepeatedText("这是一个很长的测试文本", 3)), []rune(generateDictionary(2000)[42]))),
+			patterns: generateDictionary(2000),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			da, err := BuildDoubleArray(tc.patterns)
+			if err != nil {
+				t.Fatalf("BuildDoubleArray() error = %v", err)
+			}
+			trie := BuildTrie(tc.patterns)
+			ac := NewAc()
+			ac.Build(tc.patterns)
+
+			if got, want := da.Search(tc.text), trie.Search(tc.text); !reflect.DeepEqual(got, want) {
+				t.Errorf("Search() = %v, Trie.Search() = %v", got, want)
+			}
+			if got, want := da.SearchList(tc.text), trie.SearchList(tc.text); !reflect.DeepEqual(got, want) {
+				t.Errorf("SearchList() = %v, Trie.SearchList() = %v", got, want)
+			}
+			if got, want := da.FindAll(tc.text), ac.FindAll(tc.text); !reflect.DeepEqual(got, want) {
+				t.Errorf("FindAll() = %+v, ACTree.FindAll() = %+v", got, want)
+			}
+			got, want := da.Scan(tc.text), ac.Scan(tc.text)
+			slices.Sort(got)
+			slices.Sort(want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Scan() = %v, ACTree.Scan() = %v", got, want)
+			}
+		})
+	}
+}
+
+// retainedBytes 返回构建函数执行后仍被引用的堆内存大小
+func retainedBytes(build func() any) (uint64, any) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	v := build()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	return after.HeapAlloc - before.HeapAlloc, v
+}
+
+// 大词典构建与搜索的基准测试，retained-B/op 为构建完成后结构本身占用的内存
+func BenchmarkDictionary(b *testing.B) {
+	words := generateDictionary(100000)
+	text := generateRepeatedText("这是一个很长的测试文本，包含中英文mixed content测试内容", 200) + strings.Join(words[:200], "")
+
+	builders := []struct {
+		name  string
+		build func() any
+	}{
+		{name: "Trie", build: func() any { return BuildTrie(words) }},
+		{name: "AC", build: func() any { ac := NewAc(); ac.Build(words); return ac }},
+		{name: "DoubleArray", build: func() any { da, _ := BuildDoubleArray(words); return da }},
+	}
+
+	for _, bd := range builders {
+		b.Run("Build_"+bd.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				retained, v := retainedBytes(bd.build)
+				b.ReportMetric(float64(retained), "retained-B/op")
+				runtime.KeepAlive(v)
+			}
+		})
+	}
+
+	trie := BuildTrie(words)
+	ac := NewAc()
+	ac.Build(words)
+	da, _ := BuildDoubleArray(words)
+
+	b.Run("Search_Trie", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			trie.SearchList(text)
+		}
+	})
+	b.Run("Search_DATrie", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			da.SearchList(text)
+		}
+	})
+	b.Run("Scan_AC", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ac.Scan(text)
+		}
+	})
+	b.Run("Scan_DAAC", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			da.Scan(text)
+		}
+	})
+}
+
+// 测试不支持的配置在构建时返回错误，并保留构建前的空双数组
+func TestDoubleArrayUnsupportedOption(t *testing.T) {
+	for _, opt := range unsupportedOptions {
+		if _, err := BuildDoubleArray([]string{"ab"}, opt); !errors.Is(err, ErrUnsupportedOption) {
+			t.Errorf("BuildDoubleArray() error = %v", err)
+		}
+		if da := NewDoubleArray(opt); da.Build([]string{"ab"}) == nil || da.Contains("ab") {
+			t.Error("Build() should fail and keep the empty double array")
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package matcher
+
+import "fmt"
+
+func ExampleBruteForceMatch() {
+	fmt.Println(BruteForceMatch("Hello, World!", "World"))
+	// Output: 7
+}
+
+func ExampleKMPMatch() {
+	pattern := "World"
+	fmt.Println(KMPMatch("Hello, World!", pattern, GetNext(pattern)))
+	// 展示next数组的计算
+	fmt.Println(GetNext("ABABC"))
+	// Output:
+	// 7
+	// [0 0 1 2 0]
+}
+
+func ExampleTrie_Search() {
+	trie := BuildTrie([]string{"he", "she", "his", "hers"})
+	// 返回位置信息
+	fmt.Println(trie.Search("ushers"))
+	// 仅返回匹配列表
+	fmt.Println(trie.SearchList("ushers"))
+	// Output:
+	// map[he:[2] hers:[2] she:[1]]
+	// [she he hers]
+}
+
+func ExampleAC_Search() {
+	ac, err := BuildAC([]string{"he", "she", "his", "hers"})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(ac.Search("ushers"))
+	// Output: map[he:[2] hers:[2] she:[1]]
+}
+
+// 通过统一的 Matcher 接口使用各种匹配算法
+func ExampleMatcher() {
+	patterns := []string{"he", "she", "his", "hers"}
+	text := "ushers"
+	for _, m := range []Matcher{NewBruteForce(), NewKMP(), NewTrie(), NewAC(), NewAc()} {
+		if err := m.Build(patterns); err != nil {
+			fmt.Println(err)
+			continue
+		}
+		first, _ := m.FindFirst(text)
+		fmt.Printf("%T: first %q", m, first.Pattern)
+		for _, match := range m.FindAll(text) {
+			fmt.Printf(", #%d %q [%d:%d]", match.Index, match.Pattern, match.Start, match.End)
+		}
+		fmt.Println()
+	}
+	// Output:
+	// *matcher.BruteForce: first "she", #1 "she" [1:4], #0 "he" [2:4], #3 "hers" [2:6]
+	// *matcher.KMP: first "she", #1 "she" [1:4], #0 "he" [2:4], #3 "hers" [2:6]
+	// *matcher.Trie: first "she", #1 "she" [1:4], #0 "he" [2:4], #3 "hers" [2:6]
+	// *matcher.AC: first "she", #1 "she" [1:4], #0 "he" [2:4], #3 "hers" [2:6]
+	// *matcher.ACTree: first "she", #1 "she" [1:4], #0 "he" [2:4], #3 "hers" [2:6]
+}
@@ -0,0 +1,358 @@
+package matcher
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// CaseFold 大小写折叠方式
+//
+// 启用大小写折叠后，模式串在构建时折叠，文本在搜索时逐字符折叠，不会生成折叠后的文本副本，
+// 匹配结果中的位置仍然是原文中的偏移。折叠后一个字符可能变成多个字符（例如 ß 折叠为 ss），
+// 此时匹配必须覆盖完整的原文字符，不会报告只匹配了某个字符一部分的结果。
+type CaseFold int
+
+const (
+	// NoCaseFold 不折叠，区分大小写（默认）
+	NoCaseFold CaseFold = iota
+	// SimpleCaseFold Unicode简单折叠，每个字符折叠为一个字符（例如 K、k 和开尔文符号 K 等价）
+	SimpleCaseFold
+	// FullCaseFold Unicode完全折叠，在简单折叠的基础上展开特殊字符（例如 ß 与 ss、ﬁ 与 fi 等价）
+	FullCaseFold
+	// TurkicCaseFold 土耳其语折叠，在完全折叠的基础上使用土耳其语规则：I 与 ı 等价，İ 与 i 等价
+	TurkicCaseFold
+)
+
+// maxFoldRunes 单个字符折叠后的最大rune数
+const maxFoldRunes = 3
+
+// String 返回折叠方式的名称
+func (f CaseFold) String() string {
+	switch f {
+	case NoCaseFold:
+		return "NoCaseFold"
+	case SimpleCaseFold:
+		return "SimpleCaseFold"
+	case FullCaseFold:
+		return "FullCaseFold"
+	case TurkicCaseFold:
+		return "TurkicCaseFold"
+	}
+	return "CaseFold(?)"
+}
+
+// fullFolds Unicode完全折叠中一个字符展开为多个字符的映射（CaseFolding.txt 中状态为F的条目）
+var fullFolds = map[rune][]rune{
+	0x00DF: {0x0073, 0x0073},         // ß
+	0x0130: {0x0069, 0x0307},         // İ
+	0x0149: {0x02BC, 0x006E},         // ŉ
+	0x01F0: {0x006A, 0x030C},         // ǰ
+	0x0390: {0x03B9, 0x0308, 0x0301}, // ΐ
+	0x03B0: {0x03C5, 0x0308, 0x0301}, // ΰ
+	0x0587: {0x0565, 0x0582},         // և
+	0x1E96: {0x0068, 0x0331},         // ẖ
+	0x1E97: {0x0074, 0x0308},         // ẗ
+	0x1E98: {0x0077, 0x030A},         // ẘ
+	0x1E99: {0x0079, 0x030A},         // ẙ
+	0x1E9A: {0x0061, 0x02BE},         // ẚ
+	0x1E9E: {0x0073, 0x0073},         // ẞ
+	0x1FB3: {0x03B1, 0x03B9},         // ᾳ
+	0x1FBC: {0x03B1, 0x03B9},         // ᾼ
+	0x1FC3: {0x03B7, 0x03B9},         // ῃ
+	0x1FCC: {0x03B7, 0x03B9},         // ῌ
+	0x1FF3: {0x03C9, 0x03B9},         // ῳ
+	0x1FFC: {0x03C9, 0x03B9},         // ῼ
+	0xFB00: {0x0066, 0x0066},         // ﬀ
+	0xFB01: {0x0066, 0x0069},         // ﬁ
+	0xFB02: {0x0066, 0x006C},         // ﬂ
+	0xFB03: {0x0066, 0x0066, 0x0069}, // ﬃ
+	0xFB04: {0x0066, 0x0066, 0x006C}, // ﬄ
+	0xFB05: {0x0073, 0x0074},         // ﬅ
+	0xFB06: {0x0073, 0x0074},         // ﬆ
+	0xFB13: {0x0574, 0x0576},         // ﬓ
+	0xFB14: {0x0574, 0x0565},         // ﬔ
+	0xFB15: {0x0574, 0x056B},         // ﬕ
+	0xFB16: {0x057E, 0x0576},         // ﬖ
+	0xFB17: {0x0574, 0x056D},         // ﬗ
+}
+
+func init() {
+	// 带下标iota的希腊字母（U+1F80～U+1FAF）折叠为对应的基本字母加 ι
+	for i := rune(0); i < 8; i++ {
+		for j, base := range []rune{0x1F00, 0x1F20, 0x1F60} {
+			lower := 0x1F80 + rune(j)*0x10 + i
+			fullFolds[lower] = []rune{base + i, 0x03B9}
+			fullFolds[lower+8] = []rune{base + i, 0x03B9}
+		}
+	}
+}
+
+// WithCaseFold 设置大小写折叠方式，默认为 NoCaseFold
+// BruteForce、KMP、Trie、ACTree 和 AC 支持该配置，折叠后相同的模式串视为重复的模式串
+func WithCaseFold(fold CaseFold) Option {
+	return func(o *options) {
+		o.fold = fold
+	}
+}
+
+// simpleFold 返回字符的简单折叠结果：同一折叠等价类中的字符返回相同的代表字符
+// 等价类包含ASCII字母时取小写ASCII字母，否则取码点最小的字符
+func simpleFold(r rune) rune {
+	if r < utf8.RuneSelf {
+		if 'A' <= r && r <= 'Z' {
+			r += 'a' - 'A'
+		}
+		return r
+	}
+	least := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		least = min(least, f)
+	}
+	if least < utf8.RuneSelf {
+		return simpleFold(least)
+	}
+	return least
+}
+
+// foldRune 将字符折叠后写入dst，返回折叠后的字符数
+func (f CaseFold) foldRune(r rune, dst *[maxFoldRunes]rune) int {
+	switch f {
+	case NoCaseFold:
+		dst[0] = r
+		return 1
+	case TurkicCaseFold:
+		switch r {
+		case 'I':
+			dst[0] = 'ı'
+			return 1
+		case 'İ':
+			dst[0] = 'i'
+			return 1
+		}
+		fallthrough
+	case FullCaseFold:
+		if expanded, ok := fullFolds[r]; ok {
+			for i, c := range expanded {
+				dst[i] = simpleFold(c)
+			}
+			return len(expanded)
+		}
+	}
+	dst[0] = simpleFold(r)
+	return 1
+}
+
+// foldPos 折叠后的字符所属原文字符的位置
+type foldPos struct {
+	start int  // 原文字符的字节偏移
+	rune  int  // 原文字符的rune偏移
+	first bool // 是否为原文字符折叠结果中的第一个字符
+
+	before edgeClass // 原文中前一个字符的边界类别，只在启用边界要求时记录
+	class  edgeClass // 原文字符的边界类别，只在启用边界要求时记录
+}
+
+// foldReader 在原文上逐个读取变换（规范化和大小写折叠）后的字符，不复制文本
+type foldReader struct {
+	opts   *options
+	text   string
+	start  int  // 当前原文字符的字节偏移
+	next   int  // 下一个原文字符的字节偏移
+	runes  int  // 已读取的原文字符数
+	space  bool // 上一个有效的原文字符是否为空白
+	gap    int  // 当前连续跳过的可忽略字符数
+	broken bool // 上一次读取的字符之前跳过的可忽略字符是否超过了上限
+	buf    [maxFoldRunes]rune
+	n, i   int // buf中的有效字符数和下一个待读取的下标
+}
+
+// reset 从原文字节偏移start（rune偏移runeStart）处开始读取
+func (fr *foldReader) reset(opts *options, text string, start, runeStart int) {
+	*fr = foldReader{opts: opts, text: text, start: start, next: start, runes: runeStart}
+	if opts.norm&NormalizeSpace == 0 {
+		return
+	}
+	// 合并空白时需要知道前一个有效字符是否为空白
+	var buf [maxFoldRunes]rune
+	for i := start; i > 0; {
+		r, size := utf8.DecodeLastRuneInString(text[:i])
+		if _, ignored := opts.foldRune(r, false, &buf); !ignored {
+			fr.space = unicode.IsSpace(r)
+			return
+		}
+		i -= size
+	}
+}
+
+// read 返回下一个变换后的字符，文本读完时第二个返回值为false
+// 变换后为空的原文字符（例如被合并的空白和可忽略字符）会被跳过
+func (fr *foldReader) read() (rune, bool) {
+	fr.broken = false
+	for fr.i == fr.n {
+		if fr.next >= len(fr.text) {
+			return 0, false
+		}
+		r, size := utf8.DecodeRuneInString(fr.text[fr.next:])
+		fr.start = fr.next
+		fr.next += size
+		fr.runes++
+		n, ignored := fr.opts.foldRune(r, fr.space, &fr.buf)
+		fr.n, fr.i = n, 0
+		if ignored {
+			fr.gap++
+			fr.broken = fr.broken || fr.opts.gapExceeded(fr.gap)
+			continue
+		}
+		fr.gap = 0
+		fr.space = unicode.IsSpace(r)
+	}
+	fr.i++
+	return fr.buf[fr.i-1], true
+}
+
+// last 判断上一次读取的字符是否为当前原文字符折叠结果中的最后一个字符
+func (fr *foldReader) last() bool {
+	return fr.i == fr.n
+}
+
+// pos 返回上一次读取的字符所属原文字符的位置
+func (fr *foldReader) pos() foldPos {
+	return foldPos{start: fr.start, rune: fr.runes - 1, first: fr.i == 1}
+}
+
+// foldRing 记录最近读取的若干个折叠字符的位置，用于由匹配长度反推匹配的起始位置
+type foldRing struct {
+	pos   []foldPos
+	mask  int
+	n     int       // 已记录的字符数
+	space bool      // 上一个有效的原文字符是否为空白
+	gap   int       // 当前连续跳过的可忽略字符数
+	prev  edgeClass // 上一个原文字符的边界类别，只在启用边界要求时记录
+}
+
+// newFoldRing 创建至少能保存size个位置的环形缓冲区
+func newFoldRing(size int) foldRing {
+	n := 1
+	for n < size {
+		n <<= 1
+	}
+	return foldRing{pos: make([]foldPos, n), mask: n - 1}
+}
+
+// push 记录一个折叠字符的位置
+func (r *foldRing) push(p foldPos) {
+	r.pos[r.n&r.mask] = p
+	r.n++
+}
+
+// back 返回倒数第k个记录的位置（k从1开始，不超过缓冲区大小）
+func (r *foldRing) back(k int) foldPos {
+	return r.pos[(r.n-k)&r.mask]
+}
+
+// foldScan AC 和 ACTree 共用的通用扫描路径，S为自动机的状态类型
+//
+// 逐个读取变换后的字符进行状态转移，处理可忽略字符的间隔上限、边界要求和拼音形式。
+type foldScan[S any] struct {
+	opts     *options
+	root     S
+	next     func(current S, r rune) S // 状态转移函数
+	output   func(state S) []int       // 状态的输出集合，负数编号为拼音形式
+	patterns []string                  // 模式串，下标与 Match.Index 对应
+	lengths  []int                     // 变换后的模式串长度
+	maxLen   int                       // 最长的变换后模式串长度
+	forms    []pinyinEntry             // 拼音形式
+	bounds   boundaries                // 边界要求
+}
+
+// scan 按扫描顺序对每个覆盖完整原文字符并满足边界要求的匹配调用fn，fn返回false时停止扫描
+// check不为nil时在消费位于字节偏移pos的字符之前调用check(pos)，返回false时停止扫描
+func (f *foldScan[S]) scan(text string, check func(pos int) bool, fn func(Match) bool) {
+	if f.bounds != nil {
+		// 起始边界在 step 中检查，这里检查完整的边界要求
+		report := fn
+		fn = func(m Match) bool {
+			return !f.bounds.accept(text, m) || report(m)
+		}
+	}
+	current := f.root
+	ring := newFoldRing(f.maxLen)
+	runeStart := 0
+	for start := 0; start < len(text); runeStart++ {
+		if check != nil && !check(start) {
+			return
+		}
+		r, size := utf8.DecodeRuneInString(text[start:])
+		var ok bool
+		if current, ok = f.step(current, &ring, r, start, start+size, runeStart, fn); !ok {
+			return
+		}
+		start += size
+	}
+}
+
+// step 消费原文中位于[start, end)的字符r变换后的所有字符，报告以该字符结尾并满足起始边界要求的匹配
+// 返回新的状态，fn要求停止时第二个返回值为false。结束边界由调用方检查
+func (f *foldScan[S]) step(current S, ring *foldRing, r rune, start, end, runeStart int, fn func(Match) bool) (S, bool) {
+	var buf [maxFoldRunes]rune
+	var class edgeClass
+	if f.bounds != nil {
+		class = classify(r)
+		defer func() { ring.prev = class }()
+	}
+	n, ignored := f.opts.foldRune(r, ring.space, &buf)
+	if ignored {
+		// 跳过的可忽略字符超过上限后，之后的匹配只能从头开始
+		if ring.gap++; f.opts.gapExceeded(ring.gap) {
+			current = f.root
+		}
+		return current, true
+	}
+	ring.gap = 0
+	ring.space = unicode.IsSpace(r)
+	if n == 0 {
+		// 变换后为空的字符不会成为匹配的结尾
+		return current, true
+	}
+	for i := 0; i < n; i++ {
+		current = f.next(current, buf[i])
+		ring.push(foldPos{start: start, rune: runeStart, first: i == 0, before: ring.prev, class: class})
+	}
+	// 只在字符的最后一个变换后的字符处报告匹配，并要求匹配从某个原文字符的开头开始
+	for _, id := range f.output(current) {
+		length, index, form := 0, id, PinyinNone
+		if id < 0 {
+			e := f.forms[formID(id)]
+			length, index, form = e.length, e.index, e.form
+		} else {
+			length = f.lengths[id]
+		}
+		p := ring.back(length)
+		if !p.first || (f.bounds != nil && !f.bounds[index].acceptStart(p.before, p.class)) {
+			continue
+		}
+		m := Match{Index: index, Pattern: f.patterns[index], Start: p.start, End: end, RuneStart: p.rune, RuneEnd: runeStart + 1, Form: form}
+		if !fn(m) {
+			return current, false
+		}
+	}
+	return current, true
+}
+
+// foldMatchAt 判断变换后的模式串是否与原文中从字节偏移start开始的完整字符匹配
+// 匹配时返回匹配结束的字节偏移和rune偏移
+func foldMatchAt(opts *options, text string, start, runeStart int, pattern []rune) (int, int, bool) {
+	var fr foldReader
+	fr.reset(opts, text, start, runeStart)
+	for i, p := range pattern {
+		r, ok := fr.read()
+		// 起始字符变换后为空时匹配不能从该字符开始，跳过的可忽略字符超过上限时匹配中断
+		if !ok || r != p || (i == 0 && fr.start != start) || (i > 0 && fr.broken) {
+			return 0, 0, false
+		}
+	}
+	if !fr.last() {
+		return 0, 0, false
+	}
+	return fr.next, fr.runes, true
+}
@@ -0,0 +1,252 @@
+package matcher
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 构建支持大小写折叠和规范化的 Matcher 实现
+func buildFoldMatchers(t *testing.T, patterns []string, opts ...Option) map[string]Matcher {
+	matchers := map[string]Matcher{
+		"BF":     NewBruteForce(opts...),
+		"KMP":    NewKMP(opts...),
+		"Trie":   NewTrie(opts...),
+		"ACTree": NewAc(opts...),
+		"AC":     NewAC(opts...),
+	}
+	for name, m := range matchers {
+		if err := m.Build(patterns); err != nil {
+			t.Fatalf("%s Build() error = %v", name, err)
+		}
+	}
+	return matchers
+}
+
+// foldReference 逐个枚举原文中的子串并变换后比较，作为折叠和规范化匹配的参考实现
+func foldReference(patterns []string, text string, opts ...Option) []Match {
+	o := newOptions(opts)
+	var result []Match
+	offsets := runeOffsets(text, utf8.RuneCountInString(text))
+	// 记录每个字符在上下文中变换后是否为空、是否为可忽略字符
+	var empty, ignored []bool
+	var buf [maxFoldRunes]rune
+	space := false
+	for _, r := range text {
+		n, skip := o.foldRune(r, space, &buf)
+		empty = append(empty, n == 0)
+		ignored = append(ignored, skip)
+		if !skip {
+			space = unicode.IsSpace(r)
+		}
+	}
+	seen := make(map[string]bool)
+	for index, pattern := range patterns {
+		key := string(o.foldString(pattern))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		for i := 0; i < len(offsets)-1; i++ {
+			// 匹配不能从变换后为空的字符开始，也不能以变换后为空的字符结尾
+			if empty[i] {
+				continue
+			}
+			gap := 0
+			for j := i + 1; j < len(offsets); j++ {
+				if ignored[j-1] {
+					gap++
+				} else {
+					gap = 0
+				}
+				if o.gapExceeded(gap) {
+					break
+				}
+				if empty[j-1] {
+					continue
+				}
+				if string(o.foldString(text[offsets[i]:offsets[j]])) == key {
+					result = append(result, Match{Index: index, Pattern: pattern, Start: offsets[i], End: offsets[j], RuneStart: i, RuneEnd: j})
+				}
+			}
+		}
+	}
+	sortMatches(result)
+	return result
+}
+
+func TestCaseFold(t *testing.T) {
+	tests := []struct {
+		name     string
+		fold     CaseFold
+		text     string
+		patterns []string
+		want     []Match
+	}{
+		{
+			name:     "ASCII",
+			fold:     SimpleCaseFold,
+			text:     "Hello, World!",
+			patterns: []string{"HELLO", "world", "hello"},
+			want: []Match{
+				{Index: 0, Pattern: "HELLO", Start: 0, End: 5, RuneStart: 0, RuneEnd: 5},
+				{Index: 1, Pattern: "world", Start: 7, End: 12, RuneStart: 7, RuneEnd: 12},
+			},
+		},
+		{
+			name:     "开尔文符号",
+			fold:     SimpleCaseFold,
+			text:     "温度273Kelvin",
+			patterns: []string{"kelvin"},
+			want: []Match{
+				{Index: 0, Pattern: "kelvin", Start: 9, End: 17, RuneStart: 5, RuneEnd: 11},
+			},
+		},
+		{
+			name:     "简单折叠不展开ß",
+			fold:     SimpleCaseFold,
+			text:     "Straße STRASSE",
+			patterns: []string{"strasse", "STRAẞE"},
+			want: []Match{
+				{Index: 1, Pattern: "STRAẞE", Start: 0, End: 7, RuneStart: 0, RuneEnd: 6},
+				{Index: 0, Pattern: "strasse", Start: 8, End: 15, RuneStart: 7, RuneEnd: 14},
+			},
+		},
+		{
+			name:     "完全折叠展开ß",
+			fold:     FullCaseFold,
+			text:     "Straße STRASSE",
+			patterns: []string{"strasse", "ss", "s"},
+			want: []Match{
+				{Index: 2, Pattern: "s", Start: 0, End: 1, RuneStart: 0, RuneEnd: 1},
+				{Index: 0, Pattern: "strasse", Start: 0, End: 7, RuneStart: 0, RuneEnd: 6},
+				{Index: 1, Pattern: "ss", Start: 4, End: 6, RuneStart: 4, RuneEnd: 5},
+				{Index: 2, Pattern: "s", Start: 8, End: 9, RuneStart: 7, RuneEnd: 8},
+				{Index: 0, Pattern: "strasse", Start: 8, End: 15, RuneStart: 7, RuneEnd: 14},
+				{Index: 2, Pattern: "s", Start: 12, End: 13, RuneStart: 11, RuneEnd: 12},
+				{Index: 1, Pattern: "ss", Start: 12, End: 14, RuneStart: 11, RuneEnd: 13},
+				{Index: 2, Pattern: "s", Start: 13, End: 14, RuneStart: 12, RuneEnd: 13},
+			},
+		},
+		{
+			name:     "连字",
+			fold:     FullCaseFold,
+			text:     "ﬁle",
+			patterns: []string{"FILE", "i"},
+			want: []Match{
+				{Index: 0, Pattern: "FILE", Start: 0, End: 5, RuneStart: 0, RuneEnd: 3},
+			},
+		},
+		{
+			name:     "土耳其语",
+			fold:     TurkicCaseFold,
+			text:     "İstanbul ISPARTA",
+			patterns: []string{"istanbul", "isparta", "ısparta"},
+			want: []Match{
+				{Index: 0, Pattern: "istanbul", Start: 0, End: 9, RuneStart: 0, RuneEnd: 8},
+				{Index: 2, Pattern: "ısparta", Start: 10, End: 17, RuneStart: 9, RuneEnd: 16},
+			},
+		},
+		{
+			name:     "不折叠",
+			fold:     NoCaseFold,
+			text:     "Hello, World!",
+			patterns: []string{"HELLO", "World"},
+			want: []Match{
+				{Index: 1, Pattern: "World", Start: 7, End: 12, RuneStart: 7, RuneEnd: 12},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ref := foldReference(tt.patterns, tt.text, WithCaseFold(tt.fold)); !reflect.DeepEqual(ref, tt.want) {
+				t.Fatalf("reference = %+v, want %+v", ref, tt.want)
+			}
+			for name, m := range buildFoldMatchers(t, tt.patterns, WithCaseFold(tt.fold)) {
+				if got := m.FindAll(tt.text); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("%s FindAll() = %+v, want %+v", name, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// 测试各实现的折叠匹配与参考实现一致
+func TestCaseFoldConsistency(t *testing.T) {
+	patterns := []string{"ss", "SSE", "ß", "straße", "ﬃ", "FI", "Σ", "ς", "k", "İ", "i", "ı", "ᾀ", "ἀι", "你好", "HeLLo"}
+	texts := []string{
+		"STRASSE straße STRAẞE",
+		"oﬃce OFFICE ofﬁce",
+		"ΣΑΣ σας ὈΔΥΣΣΕΎΣ",
+		"K k K İi Iı",
+		"ᾈ ᾀ ἀι ἈΙ",
+		"你好HELLO，hello世界",
+		"\xffß\xe4",
+	}
+	for _, fold := range []CaseFold{NoCaseFold, SimpleCaseFold, FullCaseFold, TurkicCaseFold} {
+		matchers := buildFoldMatchers(t, patterns, WithCaseFold(fold))
+		for _, text := range texts {
+			want := foldReference(patterns, text, WithCaseFold(fold))
+			var wantFirst Match
+			found := false
+			for _, m := range want {
+				if better(m, wantFirst, found) {
+					wantFirst, found = m, true
+				}
+			}
+			for name, m := range matchers {
+				if got := m.FindAll(text); !reflect.DeepEqual(got, want) {
+					t.Errorf("%v %s FindAll(%q) = %+v, want %+v", fold, name, text, got, want)
+				}
+				if got, ok := m.FindFirst(text); ok != found || got != wantFirst {
+					t.Errorf("%v %s FindFirst(%q) = %+v, %v, want %+v, %v", fold, name, text, got, ok, wantFirst, found)
+				}
+				if got := m.Contains(text); got != found {
+					t.Errorf("%v %s Contains(%q) = %v, want %v", fold, name, text, got, found)
+				}
+			}
+
+			// 流式扫描逐字节写入
+			ac := matchers["AC"].(*AC)
+			var got []Match
+			s := ac.NewStream(func(m Match) bool {
+				got = append(got, m)
+				return true
+			})
+			for i := 0; i < len(text); i++ {
+				s.Write([]byte{text[i]})
+			}
+			s.Flush()
+			sortMatches(got)
+			if !slices.Equal(got, want) {
+				t.Errorf("%v Stream(%q) = %+v, want %+v", fold, text, got, want)
+			}
+		}
+	}
+}
+
+func TestCaseFoldTrieSearch(t *testing.T) {
+	trie := BuildTrie([]string{"Go", "GOLANG"}, WithCaseFold(SimpleCaseFold))
+	text := "go GoLang gO"
+	want := map[string][]int{"Go": {0, 3, 10}, "GOLANG": {3}}
+	if got := trie.Search(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("Search() = %v, want %v", got, want)
+	}
+	if got := trie.SearchList(text); !reflect.DeepEqual(got, []string{"Go", "GOLANG"}) {
+		t.Errorf("SearchList() = %v", got)
+	}
+}
+
+func TestCaseFoldString(t *testing.T) {
+	for _, fold := range []CaseFold{NoCaseFold, SimpleCaseFold, FullCaseFold, TurkicCaseFold, CaseFold(9)} {
+		if fold.String() == "" {
+			t.Errorf("CaseFold(%d).String() is empty", int(fold))
+		}
+	}
+	if got := FullCaseFold.String(); got != "FullCaseFold" {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -0,0 +1,381 @@
+package matcher
+
+import (
+	"fmt"
+	"slices"
+)
+
+// acIndex 增量修改AC自动机时维护的辅助索引
+type acIndex struct {
+	failKids map[*ACNode][]*ACNode // 失败指针的反向边：失败指针指向该节点的所有节点
+	failPos  map[*ACNode]int       // 节点在其失败指针目标的 failKids 中的位置
+	owners   map[*ACNode][]int     // 在该节点结尾的所有模式串和拼音形式的编号，按构建时的插入顺序排列
+}
+
+// link 记录v的失败指针指向f
+func (inc *acIndex) link(v, f *ACNode) {
+	inc.failPos[v] = len(inc.failKids[f])
+	inc.failKids[f] = append(inc.failKids[f], v)
+}
+
+// unlink 删除v的失败指针反向边
+func (inc *acIndex) unlink(v *ACNode) {
+	kids := inc.failKids[v.fail]
+	pos, last := inc.failPos[v], kids[len(kids)-1]
+	kids[pos] = last
+	inc.failPos[last] = pos
+	inc.failKids[v.fail] = kids[:len(kids)-1]
+	delete(inc.failPos, v)
+}
+
+// setFail 修改v的失败指针并更新反向边
+func (inc *acIndex) setFail(v, f *ACNode) {
+	inc.unlink(v)
+	v.fail = f
+	inc.link(v, f)
+}
+
+// own 记录编号为id的模式串或拼音形式在节点结尾
+func (inc *acIndex) own(node *ACNode, id int) {
+	owners := inc.owners[node]
+	pos := slices.IndexFunc(owners, func(other int) bool {
+		return entryBefore(id, other)
+	})
+	if pos < 0 {
+		pos = len(owners)
+	}
+	inc.owners[node] = slices.Insert(owners, pos, id)
+}
+
+// entryBefore 判断编号a在构建时是否先于编号b插入：模式串按下标排在所有拼音形式之前
+func entryBefore(a, b int) bool {
+	if (a >= 0) != (b >= 0) {
+		return a >= 0
+	}
+	if a >= 0 {
+		return a < b
+	}
+	return formID(a) < formID(b)
+}
+
+// ensureIndex 返回增量修改使用的辅助索引，不存在时先构建失败指针再创建索引
+func (ac *AC) ensureIndex() *acIndex {
+	if ac.inc != nil {
+		return ac.inc
+	}
+	if ac.dirty || ac.root.fail == nil {
+		ac.BuildFail()
+	}
+	inc := &acIndex{
+		failKids: make(map[*ACNode][]*ACNode),
+		failPos:  make(map[*ACNode]int),
+		owners:   make(map[*ACNode][]int),
+	}
+	queue := []*ACNode{ac.root}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		current.eachChild(func(_ uint16, child *ACNode) {
+			inc.link(child, child.fail)
+			queue = append(queue, child)
+		})
+	}
+	for i, pattern := range ac.patterns {
+		if path, _ := ac.lookupPath(pattern); pattern != "" && path != nil {
+			inc.own(path[len(path)-1], i)
+		}
+	}
+	for k, e := range ac.forms {
+		if path, _ := ac.lookupPath(e.text); e.text != "" && path != nil {
+			inc.own(path[len(path)-1], formID(k))
+		}
+	}
+	ac.inc = inc
+	return inc
+}
+
+// lookupPath 返回变换后的文本在Trie树中经过的节点（从根节点开始）和每条边的字符索引，不存在时返回nil
+func (ac *AC) lookupPath(text string) ([]*ACNode, []uint16) {
+	runes := ac.opts.foldString(text)
+	path := make([]*ACNode, 1, len(runes)+1)
+	path[0] = ac.root
+	classes := make([]uint16, 0, len(runes))
+	node := ac.root
+	for _, r := range runes {
+		class := ac.charClass(r)
+		if class == otherClass {
+			return nil, nil
+		}
+		if node = node.child(class); node == nil {
+			return nil, nil
+		}
+		path = append(path, node)
+		classes = append(classes, class)
+	}
+	return path, classes
+}
+
+// Add 向已构建的AC自动机中添加一个模式串并返回其下标，只更新受影响的失败指针和输出集合，无需再调用 BuildFail
+// 空模式串和变换后为空的模式串只占用下标。模式串中的新字符会被追加到字符映射中，字符集超过上限时返回 ErrAlphabetTooLarge
+func (ac *AC) Add(pattern string) (int, error) {
+	ac.ensureIndex()
+	forms := ac.opts.pinyinForms(pattern, len(ac.patterns))
+	// 先为所有新字符分配索引，字符集超过上限时不做任何修改
+	texts := []string{pattern}
+	for _, e := range forms {
+		texts = append(texts, e.text)
+	}
+	classes := make([][]uint16, len(texts))
+	for i, text := range texts {
+		for _, r := range ac.opts.foldString(text) {
+			class, err := ac.charIndex(r)
+			if err != nil {
+				return -1, err
+			}
+			classes[i] = append(classes[i], class)
+		}
+	}
+
+	index := len(ac.patterns)
+	ac.patterns = append(ac.patterns, pattern)
+	ac.lengths = append(ac.lengths, len(classes[0]))
+	ac.bounds = ac.opts.appendBoundaries(ac.bounds, pattern)
+	if len(classes[0]) == 0 {
+		return index, nil
+	}
+	ac.addEntry(classes[0], index)
+	for i, e := range forms {
+		ac.forms = append(ac.forms, e)
+		ac.addEntry(classes[i+1], formID(len(ac.forms)-1))
+	}
+	return index, nil
+}
+
+// addEntry 插入字符索引序列为classes、编号为id的模式串或拼音形式，更新受影响的失败指针和输出集合
+func (ac *AC) addEntry(classes []uint16, id int) {
+	ac.dropDFA()
+	inc := ac.inc
+	ac.maxLen = max(ac.maxLen, len(classes))
+	node := ac.root
+	var created []*ACNode
+	for _, class := range classes {
+		child := node.child(class)
+		if child == nil {
+			child = ac.newNode()
+			node.setChild(class, child)
+			ac.attach(node, class, child)
+			created = append(created, child)
+		}
+		node = child
+	}
+	inc.own(node, id)
+	node.isEnd = true
+	node.index = inc.owners[node][0]
+	// 新节点按深度从浅到深刷新，保证刷新时失败指针目标的输出集合已经是最新的
+	for _, n := range created {
+		ac.refresh(n)
+	}
+	if len(created) == 0 {
+		ac.refresh(node)
+	}
+}
+
+// attach 为parent的新子节点u（字符索引为class）设置失败指针，并把应当指向u的已有节点的失败指针改为u
+func (ac *AC) attach(parent *ACNode, class uint16, u *ACNode) {
+	inc := ac.inc
+	fail := ac.root
+	if parent != ac.root {
+		f := parent.fail
+		for f != ac.root && f.child(class) == nil {
+			f = f.fail
+		}
+		if next := f.child(class); next != nil {
+			fail = next
+		}
+	}
+	u.fail = fail
+	inc.link(u, fail)
+
+	// 失败指针链经过parent的节点x中，如果x与parent之间没有其他节点拥有class子节点，
+	// x的class子节点的失败指针应改为u；x自身拥有class子节点时，x之下的节点不受影响
+	stack := slices.Clone(inc.failKids[parent])
+	for len(stack) > 0 {
+		x := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if x == u {
+			continue
+		}
+		if v := x.child(class); v != nil {
+			inc.setFail(v, u)
+			continue
+		}
+		stack = append(stack, inc.failKids[x]...)
+	}
+}
+
+// refresh 重新计算节点及失败指针指向它的所有节点（递归）的输出集合
+func (ac *AC) refresh(n *ACNode) {
+	queue := []*ACNode{n}
+	for len(queue) > 0 {
+		x := queue[0]
+		queue = queue[1:]
+		x.output = x.output[:0]
+		if x != ac.root {
+			x.output = append(x.output, x.fail.output...)
+		}
+		if x.isEnd {
+			x.output = append(x.output, x.index)
+		}
+		queue = append(queue, ac.inc.failKids[x]...)
+	}
+}
+
+// Remove 从已构建的AC自动机中删除模式串，按配置变换后与其相同的模式串以及它们的拼音形式也会被删除，
+// 只更新受影响的失败指针和输出集合。删除的下标不会被之后添加的模式串复用，模式串不存在时返回false
+func (ac *AC) Remove(pattern string) bool {
+	inc := ac.ensureIndex()
+	path, classes := ac.lookupPath(pattern)
+	if len(path) <= 1 {
+		return false
+	}
+	var removed []int
+	for _, id := range inc.owners[path[len(path)-1]] {
+		if id >= 0 {
+			removed = append(removed, id)
+		}
+	}
+	if len(removed) == 0 {
+		return false
+	}
+	ac.dropDFA()
+	for _, index := range removed {
+		ac.patterns[index] = ""
+		ac.removeEntry(path, classes, index)
+		// 同一模式串的拼音形式在 forms 中连续存放
+		k, _ := slices.BinarySearchFunc(ac.forms, index, func(e pinyinEntry, index int) int {
+			return e.index - index
+		})
+		for ; k < len(ac.forms) && ac.forms[k].index == index; k++ {
+			if formPath, formClasses := ac.lookupPath(ac.forms[k].text); formPath != nil {
+				ac.removeEntry(formPath, formClasses, formID(k))
+			}
+			ac.forms[k].text = ""
+		}
+	}
+	return true
+}
+
+// removeEntry 删除在path末端节点结尾的编号id，节点不再是任何模式串的结尾时删除不再需要的节点
+func (ac *AC) removeEntry(path []*ACNode, classes []uint16, id int) {
+	inc := ac.inc
+	node := path[len(path)-1]
+	inc.owners[node] = slices.DeleteFunc(inc.owners[node], func(other int) bool {
+		return other == id
+	})
+	if owners := inc.owners[node]; len(owners) > 0 {
+		node.index = owners[0]
+		ac.refresh(node)
+		return
+	}
+	delete(inc.owners, node)
+	node.isEnd = false
+	ac.refresh(node)
+
+	// 从深到浅删除不再是任何模式串前缀的节点，失败指针指向被删除节点的节点改为指向它的失败指针目标，
+	// 被删除的节点不是模式串结尾，输出集合与其失败指针目标相同，因此这些节点的输出集合不变
+	for i := len(path) - 1; i > 0; i-- {
+		z := path[i]
+		if z.isEnd || !z.leaf() {
+			break
+		}
+		path[i-1].removeChild(classes[i-1])
+		for _, kid := range inc.failKids[z] {
+			kid.fail = z.fail
+			inc.link(kid, z.fail)
+		}
+		delete(inc.failKids, z)
+		inc.unlink(z)
+	}
+}
+
+// acEntry 模式串或拼音形式的内容，用于比较两个AC自动机
+type acEntry struct {
+	index int
+	form  PinyinForm
+	text  string
+}
+
+// entry 返回输出集合中的编号对应的模式串或拼音形式
+func (ac *AC) entry(id int) acEntry {
+	if id < 0 {
+		e := ac.forms[formID(id)]
+		return acEntry{index: e.index, form: e.form, text: e.text}
+	}
+	return acEntry{index: id, text: ac.patterns[id]}
+}
+
+// Verify 检查增量修改后的AC自动机是否与使用相同模式串集合（已删除的模式串视为空模式串）重新构建的结果一致，
+// 比较Trie树结构、失败指针和输出集合，不一致时返回描述第一处差异的错误
+func (ac *AC) Verify() error {
+	fresh := &AC{charMap: make(map[rune]uint16, 256), maxChar: 1, opts: ac.opts}
+	if err := fresh.Build(ac.patterns); err != nil {
+		return err
+	}
+	runes := make([]rune, fresh.maxChar)
+	for r, class := range fresh.charMap {
+		runes[class] = r
+	}
+
+	// 按字符同步遍历两棵Trie树，建立节点对应关系
+	pairs := map[*ACNode]*ACNode{fresh.root: ac.root}
+	paths := map[*ACNode]string{fresh.root: ""}
+	order := []*ACNode{fresh.root}
+	for i := 0; i < len(order); i++ {
+		f := order[i]
+		n := pairs[f]
+		count := 0
+		var err error
+		f.eachChild(func(class uint16, child *ACNode) {
+			r := runes[class]
+			paths[child] = paths[f] + string(r)
+			count++
+			other := n.child(ac.charClass(r))
+			if other == nil {
+				if err == nil {
+					err = fmt.Errorf("matcher: AC node %q is missing", paths[child])
+				}
+				return
+			}
+			pairs[child] = other
+			order = append(order, child)
+		})
+		if err != nil {
+			return err
+		}
+		n.eachChild(func(uint16, *ACNode) {
+			count--
+		})
+		if count != 0 {
+			return fmt.Errorf("matcher: AC node %q has %d extra children", paths[f], -count)
+		}
+	}
+
+	for _, f := range order {
+		n := pairs[f]
+		if f != fresh.root && pairs[f.fail] != n.fail {
+			return fmt.Errorf("matcher: AC node %q fail link differs, want %q", paths[f], paths[f.fail])
+		}
+		if f.isEnd != n.isEnd || (f.isEnd && fresh.entry(f.index) != ac.entry(n.index)) {
+			return fmt.Errorf("matcher: AC node %q end state differs", paths[f])
+		}
+		if len(f.output) != len(n.output) {
+			return fmt.Errorf("matcher: AC node %q has %d outputs, want %d", paths[f], len(n.output), len(f.output))
+		}
+		for j, id := range f.output {
+			if fresh.entry(id) != ac.entry(n.output[j]) {
+				return fmt.Errorf("matcher: AC node %q output %d differs", paths[f], j)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package matcher
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestACAddRemove(t *testing.T) {
+	ac, err := BuildAC([]string{"he", "she"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pattern := range []string{"his", "hers", "e"} {
+		if _, err := ac.Add(pattern); err != nil {
+			t.Fatal(err)
+		}
+		if err := ac.Verify(); err != nil {
+			t.Fatalf("Add(%q) Verify() = %v", pattern, err)
+		}
+	}
+	text := "ushers his"
+	want := []Match{
+		{Index: 1, Pattern: "she", Start: 1, End: 4, RuneStart: 1, RuneEnd: 4},
+		{Index: 0, Pattern: "he", Start: 2, End: 4, RuneStart: 2, RuneEnd: 4},
+		{Index: 3, Pattern: "hers", Start: 2, End: 6, RuneStart: 2, RuneEnd: 6},
+		{Index: 4, Pattern: "e", Start: 3, End: 4, RuneStart: 3, RuneEnd: 4},
+		{Index: 2, Pattern: "his", Start: 7, End: 10, RuneStart: 7, RuneEnd: 10},
+	}
+	if got := ac.FindAll(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll() = %+v, want %+v", got, want)
+	}
+
+	if !ac.Remove("she") || ac.Remove("she") || ac.Remove("xyz") || ac.Remove("") {
+		t.Fatal("Remove() result is wrong")
+	}
+	if err := ac.Verify(); err != nil {
+		t.Fatalf("Remove() Verify() = %v", err)
+	}
+	want = slices.Delete(want, 0, 1)
+	if got := ac.FindAll(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll() = %+v, want %+v", got, want)
+	}
+
+	// 删除的下标不会被复用
+	if index, err := ac.Add("she"); err != nil || index != 5 {
+		t.Errorf("Add() = %d, %v, want 5", index, err)
+	}
+	if err := ac.Verify(); err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+}
+
+func TestACAddAfterInsert(t *testing.T) {
+	ac := NewAC()
+	if _, err := ac.Add("abc"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ac.Insert("bc"); err != nil {
+		t.Fatal(err)
+	}
+	// Insert 之后未构建失败指针，与重新构建的结果不一致
+	if err := ac.Verify(); err == nil {
+		t.Error("Verify() should fail before BuildFail")
+	}
+	// Add 会先构建失败指针
+	if _, err := ac.Add("c"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ac.Verify(); err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+	if got := len(ac.FindAll("abc")); got != 3 {
+		t.Errorf("len(FindAll()) = %d, want 3", got)
+	}
+}
+
+func TestACIncrementalOptions(t *testing.T) {
+	ac, err := BuildAC([]string{"Hello"}, WithCaseFold(SimpleCaseFold), WithPinyin(PinyinFull|PinyinInitials, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pattern := range []string{"敏感词", "HELLO", "mgc"} {
+		if _, err := ac.Add(pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ac.Verify(); err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+	// "mgc" 同时是模式串和拼音形式，模式串优先
+	if m, ok := ac.FindFirst("MGC"); !ok || m.Index != 3 || m.Form != PinyinNone {
+		t.Errorf("FindFirst() = %+v, %v", m, ok)
+	}
+	if !ac.Remove("mgc") {
+		t.Fatal("Remove(mgc) = false")
+	}
+	if m, ok := ac.FindFirst("MGC"); !ok || m.Index != 1 || m.Form != PinyinInitials {
+		t.Errorf("FindFirst() = %+v, %v", m, ok)
+	}
+	// 删除 "hello" 同时删除折叠后相同的 "HELLO"
+	if !ac.Remove("hello") || ac.Contains("hello") {
+		t.Error("Remove(hello) failed")
+	}
+	if !ac.Remove("敏感词") || ac.Contains("minganci mgc 敏感词") {
+		t.Error("Remove(敏感词) failed")
+	}
+	if err := ac.Verify(); err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+}
+
+// 随机添加和删除模式串，每一步都与重新构建的AC自动机比较
+func TestACIncrementalRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, alphabet := range []string{"ab", "abcd", cjkAlphabet(300)} {
+		chars := []rune(alphabet)
+		randomString := func(maxLen int) string {
+			runes := make([]rune, 1+rng.Intn(maxLen))
+			for i := range runes {
+				runes[i] = chars[rng.Intn(len(chars))]
+			}
+			return string(runes)
+		}
+		var initial []string
+		for i := 0; i < 20; i++ {
+			initial = append(initial, randomString(5))
+		}
+		ac, err := BuildAC(initial)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for step := 0; step < 300; step++ {
+			if rng.Intn(3) == 0 {
+				ac.Remove(ac.patterns[rng.Intn(len(ac.patterns))])
+			} else if _, err := ac.Add(randomString(6)); err != nil {
+				t.Fatal(err)
+			}
+			if err := ac.Verify(); err != nil {
+				t.Fatalf("step %d: %v", step, err)
+			}
+			text := randomString(40)
+			fresh, _ := BuildAC(ac.patterns)
+			if got, want := ac.FindAll(text), fresh.FindAll(text); !reflect.DeepEqual(got, want) {
+				t.Fatalf("step %d: FindAll(%q) = %v, want %v", step, text, got, want)
+			}
+		}
+	}
+}
+
+// cjkAlphabet 返回由n个不同汉字组成的字符串，用于测试大字符集下的紧凑切片布局
+func cjkAlphabet(n int) string {
+	runes := make([]rune, n)
+	for i := range runes {
+		runes[i] = rune(0x4E00 + i)
+	}
+	return string(runes)
+}
@@ -0,0 +1,270 @@
+package matcher
+
+// GetNext 计算模式串的next数组
+func GetNext(pattern string) []int {
+	patternRunes := []rune(pattern)
+	m := len(patternRunes)
+	next := make([]int, m)
+	if m == 0 {
+		return next
+	}
+	next[0] = 0
+	j := 0
+
+	for i := 1; i < m; {
+		if patternRunes[i] == patternRunes[j] {
+			j++
+			next[i] = j
+			i++
+		} else if j > 0 {
+			j = next[j-1]
+		} else {
+			next[i] = 0
+			i++
+		}
+	}
+	return next
+}
+
+// KMPMatch 使用KMP算法查找模式串在主串中的位置
+func KMPMatch(text string, pattern string, next []int) int {
+	// 将字符串转换为rune切片，以支持中文
+	textRunes := []rune(text)
+	patternRunes := []rune(pattern)
+
+	n := len(textRunes)
+	m := len(patternRunes)
+
+	if m == 0 {
+		return 0
+	}
+	if m > n {
+		return -1
+	}
+	return kmpIndex(textRunes, patternRunes, next, 0)
+}
+
+// KMPMatchAll 使用KMP算法查找模式串在主串中出现的所有位置（允许重叠）
+// 返回所有匹配的起始位置（按rune计算），模式串为空时返回nil
+func KMPMatchAll(text string, pattern string, next []int) []int {
+	if pattern == "" {
+		return nil
+	}
+	return kmpIndexAll([]rune(text), []rune(pattern), next)
+}
+
+// kmpIndex 从from位置开始查找模式串第一次出现的位置
+func kmpIndex(textRunes, patternRunes []rune, next []int, from int) int {
+	n, m := len(textRunes), len(patternRunes)
+	i, j := from, 0
+	for i < n && j < m {
+		if textRunes[i] == patternRunes[j] {
+			i++
+			j++
+		} else if j > 0 {
+			j = next[j-1]
+		} else {
+			i++
+		}
+	}
+
+	if j == m {
+		return i - j
+	}
+	return -1
+}
+
+// kmpIndexAll 查找模式串出现的所有位置，匹配成功后利用next数组继续匹配
+func kmpIndexAll(textRunes, patternRunes []rune, next []int) []int {
+	var result []int
+	n, m := len(textRunes), len(patternRunes)
+	i, j := 0, 0
+	for i < n {
+		if textRunes[i] == patternRunes[j] {
+			i++
+			j++
+			if j == m {
+				result = append(result, i-m)
+				j = next[j-1]
+			}
+		} else if j > 0 {
+			j = next[j-1]
+		} else {
+			i++
+		}
+	}
+	return result
+}
+
+// KMP 基于KMP算法的多模式串匹配器，逐个模式串进行匹配
+type KMP struct {
+	patterns [][]rune // 模式串的rune切片（启用大小写折叠或规范化时为变换后的字符）
+	values   []string // 模式串原始值
+	indexes  []int    // 模式串在构建时传入的模式串集合中的下标
+	next     [][]int  // 每个模式串的next数组
+	opts     options  // 构建配置，使用匹配语义和字符变换
+}
+
+// NewKMP 创建新的KMP匹配器
+func NewKMP(opts ...Option) *KMP {
+	return &KMP{opts: newOptions(opts)}
+}
+
+// Build 构建KMP匹配器，预先计算每个模式串的next数组
+// 不支持拼音形式和边界要求，配置了 WithPinyin、WithBoundary 或 WithPatternBoundary 时返回 ErrUnsupportedOption
+func (k *KMP) Build(patterns []string) error {
+	if err := k.opts.noPinyin("KMP"); err != nil {
+		return err
+	}
+	if err := k.opts.noBoundary("KMP"); err != nil {
+		return err
+	}
+	k.patterns = make([][]rune, 0, len(patterns))
+	k.values = make([]string, 0, len(patterns))
+	k.indexes = make([]int, 0, len(patterns))
+	seen := make(map[string]bool, len(patterns))
+	k.next = make([][]int, 0, len(patterns))
+	for i, pattern := range patterns {
+		folded := k.opts.foldString(pattern)
+		key := string(folded)
+		// 忽略空模式串和变换后为空的模式串（例如只由可忽略字符组成），重复的模式串保留第一次出现的下标
+		if len(folded) == 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		k.patterns = append(k.patterns, folded)
+		k.values = append(k.values, pattern)
+		k.indexes = append(k.indexes, i)
+		k.next = append(k.next, GetNext(key))
+	}
+	return nil
+}
+
+// FindFirst 返回文本中最先出现的匹配
+func (k *KMP) FindFirst(text string) (Match, bool) {
+	if k.opts.kind != MatchAll {
+		// 不重叠语义下最先的匹配即结果中的第一个
+		if matches := k.FindAll(text); len(matches) > 0 {
+			return matches[0], true
+		}
+		return Match{}, false
+	}
+	if k.opts.transformed() {
+		return k.findFirstFold(text)
+	}
+	textRunes := []rune(text)
+	offsets := runeOffsets(text, len(textRunes))
+	var best Match
+	found := false
+	for i, p := range k.patterns {
+		pos := kmpIndex(textRunes, p, k.next[i], 0)
+		if pos == -1 {
+			continue
+		}
+		m := k.match(i, pos, offsets)
+		if better(m, best, found) {
+			best, found = m, true
+		}
+	}
+	return best, found
+}
+
+// FindAll 按匹配语义返回文本中的匹配
+func (k *KMP) FindAll(text string) []Match {
+	var result []Match
+	if k.opts.transformed() {
+		for i := range k.patterns {
+			k.scanFold(text, i, func(m Match) bool {
+				result = append(result, m)
+				return true
+			})
+		}
+		return resolveMatches(result, k.opts.kind)
+	}
+	textRunes := []rune(text)
+	offsets := runeOffsets(text, len(textRunes))
+	for i, p := range k.patterns {
+		for _, pos := range kmpIndexAll(textRunes, p, k.next[i]) {
+			result = append(result, k.match(i, pos, offsets))
+		}
+	}
+	return resolveMatches(result, k.opts.kind)
+}
+
+// match 根据第i个模式串的起始位置（按rune计算）构造匹配结果
+func (k *KMP) match(i, pos int, offsets []int) Match {
+	end := pos + len(k.patterns[i])
+	return Match{
+		Index:     k.indexes[i],
+		Pattern:   k.values[i],
+		Start:     offsets[pos],
+		End:       offsets[end],
+		RuneStart: pos,
+		RuneEnd:   end,
+	}
+}
+
+// Contains 判断文本中是否包含任一模式串
+func (k *KMP) Contains(text string) bool {
+	if k.opts.transformed() {
+		_, found := k.findFirstFold(text)
+		return found
+	}
+	textRunes := []rune(text)
+	for i, p := range k.patterns {
+		if kmpIndex(textRunes, p, k.next[i], 0) != -1 {
+			return true
+		}
+	}
+	return false
+}
+
+// scanFold 启用大小写折叠或规范化时使用KMP算法逐个读取变换后的文本，查找第i个模式串，
+// 按出现顺序对每个匹配调用fn，fn返回false时停止查找
+func (k *KMP) scanFold(text string, i int, fn func(Match) bool) {
+	p, next := k.patterns[i], k.next[i]
+	m := len(p)
+	ring := newFoldRing(m)
+	var fr foldReader
+	fr.reset(&k.opts, text, 0, 0)
+	j := 0
+	for r, ok := fr.read(); ok; r, ok = fr.read() {
+		ring.push(fr.pos())
+		if fr.broken {
+			// 跳过的可忽略字符超过上限，之前的部分匹配失效
+			j = 0
+		}
+		for j > 0 && r != p[j] {
+			j = next[j-1]
+		}
+		if r == p[j] {
+			j++
+		}
+		if j < m {
+			continue
+		}
+		// 只报告覆盖完整原文字符的匹配
+		if start := ring.back(m); start.first && fr.last() {
+			match := Match{Index: k.indexes[i], Pattern: k.values[i], Start: start.start, End: fr.next, RuneStart: start.rune, RuneEnd: fr.runes}
+			if !fn(match) {
+				return
+			}
+		}
+		j = next[j-1]
+	}
+}
+
+// findFirstFold 启用大小写折叠或规范化时返回文本中最先出现的匹配
+func (k *KMP) findFirstFold(text string) (Match, bool) {
+	var best Match
+	found := false
+	for i := range k.patterns {
+		k.scanFold(text, i, func(m Match) bool {
+			if better(m, best, found) {
+				best, found = m, true
+			}
+			return false
+		})
+	}
+	return best, found
+}
@@ -1,4 +1,4 @@
-package main
+package matcher
 
 import (
 	"strconv"
@@ -42,7 +42,7 @@ func BenchmarkKMPMatch(b *testing.B) {
 
 	// 预处理所有测试用例的next数组
 	for i := range testCases {
-		testCases[i].next = getNext(testCases[i].pattern)
+		testCases[i].next = GetNext(testCases[i].pattern)
 	}
 
 	// 运行基准测试
@@ -58,7 +58,7 @@ func BenchmarkKMPMatch(b *testing.B) {
 	}
 }
 
-// 测试 getNext 函数
+// 测试 GetNext 函数
 func TestGetNext(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -94,14 +94,14 @@ func TestGetNext(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := getNext(tt.pattern)
+			got := GetNext(tt.pattern)
 			if len(got) != len(tt.expected) {
-				t.Errorf("getNext() length = %v, want %v", len(got), len(tt.expected))
+				t.Errorf("GetNext() length = %v, want %v", len(got), len(tt.expected))
 				return
 			}
 			for i := range got {
 				if got[i] != tt.expected[i] {
-					t.Errorf("getNext()[%d] = %v, want %v", i, got[i], tt.expected[i])
+					t.Errorf("GetNext()[%d] = %v, want %v", i, got[i], tt.expected[i])
 				}
 			}
 		})
@@ -156,7 +156,7 @@ func TestKMPMatch(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			next := getNext(tt.pattern)
+			next := GetNext(tt.pattern)
 			got := KMPMatch(tt.text, tt.pattern, next)
 			if got != tt.expected {
 				t.Errorf("KMPMatch() = %v, want %v", got, tt.expected)
@@ -0,0 +1,138 @@
+package matcher
+
+import (
+	"context"
+	"fmt"
+)
+
+// ctxCheckInterval 扫描时每处理多少个字符检查一次 ctx 是否结束
+const ctxCheckInterval = 1024
+
+// Limits 扫描的资源限制，字段为零时表示不限制
+type Limits struct {
+	MaxMatches   int // 最多保留的匹配数量（按匹配语义选择之前的重叠匹配计数）
+	MaxInputSize int // 最多扫描的字节数，超出部分不扫描
+}
+
+// Limit 触发的限制类型
+type Limit uint8
+
+const (
+	// LimitContext ctx 被取消或超时
+	LimitContext Limit = iota + 1
+	// LimitMatches 匹配数量超过 Limits.MaxMatches
+	LimitMatches
+	// LimitInputSize 输入超过 Limits.MaxInputSize
+	LimitInputSize
+)
+
+// String 返回限制类型的名称
+func (l Limit) String() string {
+	switch l {
+	case LimitContext:
+		return "LimitContext"
+	case LimitMatches:
+		return "LimitMatches"
+	case LimitInputSize:
+		return "LimitInputSize"
+	}
+	return fmt.Sprintf("Limit(%d)", uint8(l))
+}
+
+// LimitError 扫描因触发限制而提前停止，同时返回的匹配为停止之前找到的部分结果
+// Limit 为 LimitContext 时 Err 为 ctx 的错误，可以使用 errors.Is 判断 context.Canceled 等
+type LimitError struct {
+	Limit  Limit // 触发的限制
+	Value  int   // 限制的值，LimitContext 时为0
+	Offset int   // 停止扫描时的字节偏移
+	Err    error // ctx 的错误
+}
+
+// Error 实现 error 接口
+func (e *LimitError) Error() string {
+	switch e.Limit {
+	case LimitContext:
+		return fmt.Sprintf("matcher: scan stopped at byte %d: %v", e.Offset, e.Err)
+	case LimitMatches:
+		return fmt.Sprintf("matcher: scan stopped at byte %d: more than %d matches", e.Offset, e.Value)
+	}
+	return fmt.Sprintf("matcher: scan stopped at byte %d: input larger than %d bytes", e.Offset, e.Value)
+}
+
+// Unwrap 返回 ctx 的错误
+func (e *LimitError) Unwrap() error {
+	return e.Err
+}
+
+// limiter 在扫描过程中检查 ctx 和资源限制，并收集匹配
+type limiter struct {
+	ctx     context.Context
+	limits  Limits
+	steps   int
+	matches []Match
+	err     *LimitError
+}
+
+// check 在处理字节偏移pos处的字符之前调用，触发限制时返回false
+func (l *limiter) check(pos int) bool {
+	if l.limits.MaxInputSize > 0 && pos >= l.limits.MaxInputSize {
+		l.err = &LimitError{Limit: LimitInputSize, Value: l.limits.MaxInputSize, Offset: pos}
+		return false
+	}
+	if l.steps%ctxCheckInterval == 0 {
+		if err := l.ctx.Err(); err != nil {
+			l.err = &LimitError{Limit: LimitContext, Offset: pos, Err: err}
+			return false
+		}
+	}
+	l.steps++
+	return true
+}
+
+// add 收集一个匹配，超过数量限制时返回false
+func (l *limiter) add(m Match) bool {
+	if l.limits.MaxInputSize > 0 && m.End > l.limits.MaxInputSize {
+		// 只保留完全位于扫描范围内的匹配
+		return true
+	}
+	if l.limits.MaxMatches > 0 && len(l.matches) >= l.limits.MaxMatches {
+		l.err = &LimitError{Limit: LimitMatches, Value: l.limits.MaxMatches, Offset: m.End}
+		return false
+	}
+	l.matches = append(l.matches, m)
+	return true
+}
+
+// result 返回收集到的匹配和触发的限制
+func (l *limiter) result(kind MatchKind) ([]Match, error) {
+	matches := resolveMatches(l.matches, kind)
+	if l.err != nil {
+		return matches, l.err
+	}
+	return matches, nil
+}
+
+// FindAllContext 同 FindAll，但在ctx结束或超出limits时提前停止扫描
+//
+// 提前停止时返回 *LimitError 和已扫描部分的匹配，这些匹配按匹配语义选择，
+// 因此不重叠语义下可能与完整扫描的结果的前缀不同。超出 MaxInputSize 时只扫描前 MaxInputSize 个字节。
+func (ac *AC) FindAllContext(ctx context.Context, text string, limits Limits) ([]Match, error) {
+	l := &limiter{ctx: ctx, limits: limits}
+	ac.each(text, l.check, l.add)
+	return l.result(ac.opts.kind)
+}
+
+// FindAllContext 同 FindAll，但在ctx结束或超出limits时提前停止扫描，见 AC.FindAllContext
+func (a *ACTree) FindAllContext(ctx context.Context, text string, limits Limits) ([]Match, error) {
+	l := &limiter{ctx: ctx, limits: limits}
+	a.each(text, l.check, l.add)
+	return l.result(a.opts.kind)
+}
+
+// FindAllContext 同 FindAll，但在ctx结束或超出limits时提前停止匹配，见 AC.FindAllContext
+// Trie 按起始位置依次匹配，超出 MaxMatches 时返回的是起始位置最靠前的匹配
+func (t *Trie) FindAllContext(ctx context.Context, text string, limits Limits) ([]Match, error) {
+	l := &limiter{ctx: ctx, limits: limits}
+	t.each(text, l.check, l.add)
+	return l.result(t.opts.kind)
+}
@@ -0,0 +1,67 @@
+package matcher
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// limitMatcher 支持带限制扫描的 Matcher 实现
+type limitMatcher interface {
+	Matcher
+	FindAllContext(ctx context.Context, text string, limits Limits) ([]Match, error)
+}
+
+func limitMatchers(patterns []string, opts ...Option) []limitMatcher {
+	ac, _ := BuildAC(patterns, opts...)
+	tree := NewAc(opts...)
+	tree.Build(patterns)
+	return []limitMatcher{ac, tree, BuildTrie(patterns, opts...)}
+}
+
+func TestFindAllContext(t *testing.T) {
+	patterns := []string{"ab", "b", "敏感词"}
+	text := "ab 敏感词 ab 敏感词 ab"
+	for _, opts := range [][]Option{nil, {WithCaseFold(SimpleCaseFold), WithBoundary(BoundaryWord)}} {
+		for _, m := range limitMatchers(patterns, opts...) {
+			want := m.FindAll(text)
+			got, err := m.FindAllContext(context.Background(), text, Limits{MaxMatches: len(want), MaxInputSize: len(text)})
+			if err != nil || !reflect.DeepEqual(got, want) {
+				t.Errorf("%T FindAllContext() = %v, %v, want %v", m, got, err, want)
+			}
+
+			// 匹配数量超过限制时返回部分结果
+			got, err = m.FindAllContext(context.Background(), text, Limits{MaxMatches: 2})
+			var limitErr *LimitError
+			if !errors.As(err, &limitErr) || limitErr.Limit != LimitMatches || limitErr.Value != 2 || len(got) != 2 {
+				t.Errorf("%T MaxMatches: got %v, %v", m, got, err)
+			}
+
+			// 只扫描输入的前缀，跨越限制的匹配不返回
+			limit := strings.Index(text, "感")
+			got, err = m.FindAllContext(context.Background(), text, Limits{MaxInputSize: limit})
+			if !errors.As(err, &limitErr) || limitErr.Limit != LimitInputSize || !reflect.DeepEqual(got, m.FindAll(text[:3])) {
+				t.Errorf("%T MaxInputSize: got %v, %v", m, got, err)
+			}
+		}
+	}
+}
+
+func TestFindAllContextCancel(t *testing.T) {
+	// 没有匹配的长文本也能及时停止
+	text := strings.Repeat("a", 1<<20)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, m := range limitMatchers([]string{"aaab", "b"}) {
+		got, err := m.FindAllContext(ctx, text, Limits{})
+		var limitErr *LimitError
+		if !errors.Is(err, context.Canceled) || !errors.As(err, &limitErr) || limitErr.Limit != LimitContext || len(got) != 0 {
+			t.Errorf("%T FindAllContext() = %v, %v", m, got, err)
+		}
+		if limitErr != nil && limitErr.Offset != 0 {
+			t.Errorf("%T stopped at %d", m, limitErr.Offset)
+		}
+	}
+}
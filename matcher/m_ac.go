@@ -0,0 +1,335 @@
+package matcher
+
+import (
+	"iter"
+	"slices"
+	"unicode/utf8"
+)
+
+// 树的节点
+type node struct {
+	fail   *node          // 失败指针
+	isEnd  bool           // 是否词组结尾
+	child  map[rune]*node // 子节点
+	output []int          // 输出集合缓存（词下标）
+	index  int            // 完整模式串在词表中的下标
+}
+
+// 初始化一个节点
+func newNode() *node {
+	return &node{
+		fail:   nil,
+		isEnd:  false,
+		child:  make(map[rune]*node),
+		output: make([]int, 0, 4), // 预分配空间
+	}
+}
+
+// ACTree ac自动机树，按rune构建，由 NewAc 创建
+type ACTree struct {
+	root    *node         // root节点
+	charSet map[rune]bool // 字符集缓存
+	words   []string      // 词表，下标与 Match.Index 对应
+	lengths []int         // 词长度缓存（按rune计算，启用大小写折叠或规范化时为变换后的长度）
+	maxLen  int           // 最长的词长度（同 lengths）
+	forms   []pinyinEntry // 词的拼音形式，输出集合中的编号 formID(k) 对应 forms[k]
+	bounds  boundaries    // 词的边界要求
+	opts    options       // 构建配置
+}
+
+// NewAc AC自动机，词匹配
+func NewAc(opts ...Option) *ACTree {
+	return &ACTree{
+		root:    newNode(),
+		charSet: make(map[rune]bool),
+		opts:    newOptions(opts),
+	}
+}
+
+// Build 构建树，会丢弃之前构建的内容
+func (a *ACTree) Build(words []string) error {
+	a.root = newNode()
+	a.charSet = make(map[rune]bool)
+	a.words = slices.Clone(words)
+	a.lengths = make([]int, len(words))
+	a.maxLen = 0
+	a.forms = a.opts.expandPinyin(words)
+	a.bounds = a.opts.appendBoundaries(nil, words...)
+
+	// 构建Trie树
+	for i, word := range words {
+		runes := a.opts.foldString(word)
+		a.lengths[i] = len(runes)
+		if word != "" {
+			a.insert(runes, i)
+		}
+	}
+	// 拼音形式在所有词之后插入，与词相同的拼音形式不会覆盖词
+	for k, e := range a.forms {
+		a.insert(a.opts.foldString(e.text), formID(k))
+	}
+
+	// 构建fail指针
+	a.BuildFail()
+	return nil
+}
+
+// insert 将变换后的词插入Trie树，id为词下标或拼音形式的编号，重复的词保留第一次出现的编号
+func (a *ACTree) insert(runes []rune, id int) {
+	if len(runes) == 0 {
+		// 变换后为空的词（例如只由可忽略字符组成）不会产生匹配
+		return
+	}
+	a.maxLen = max(a.maxLen, len(runes))
+	nodePtr := a.root
+	for _, r := range runes {
+		a.charSet[r] = true
+		if _, ok := nodePtr.child[r]; !ok {
+			nodePtr.child[r] = newNode()
+		}
+		nodePtr = nodePtr.child[r]
+	}
+	if !nodePtr.isEnd {
+		nodePtr.isEnd = true
+		nodePtr.index = id
+	}
+}
+
+// BuildFail 构建树的fail指针
+func (a *ACTree) BuildFail() {
+	// 使用切片替代通用队列，提高性能
+	queue := make([]*node, 0, 256)
+	queue = append(queue, a.root)
+	a.root.fail = a.root
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		// 处理当前节点的所有子节点
+		for r, child := range current.child {
+			queue = append(queue, child)
+
+			if current == a.root {
+				child.fail = a.root
+				if child.isEnd {
+					child.output = append(child.output, child.index)
+				}
+				continue
+			}
+
+			// 修正的失败指针构建逻辑
+			failNode := current.fail
+			for failNode != a.root && failNode.child[r] == nil {
+				failNode = failNode.fail
+			}
+
+			if failNode.child[r] != nil {
+				child.fail = failNode.child[r]
+				// 合并输出集合
+				child.output = append(child.output, child.fail.output...)
+			} else {
+				child.fail = a.root
+			}
+
+			// 如果是模式串结尾，添加到输出集合
+			if child.isEnd {
+				child.output = append(child.output, child.index)
+			}
+		}
+	}
+}
+
+// findNextState 查找下一个状态（优化的状态转移）
+func (a *ACTree) findNextState(current *node, r rune) *node {
+	for current != a.root && current.child[r] == nil {
+		current = current.fail
+	}
+	if current.child[r] != nil {
+		return current.child[r]
+	}
+	return a.root
+}
+
+// Scan 扫描树
+// 匹配语义不是 MatchAll 时只返回选中的不重叠匹配
+func (a *ACTree) Scan(text string) []string {
+	result := make([]string, 0, 64) // 预分配结果空间
+	if a.opts.kind != MatchAll || a.opts.generic() || len(a.forms) > 0 {
+		for _, m := range a.FindAll(text) {
+			result = append(result, m.Pattern)
+		}
+		return result
+	}
+	current := a.root
+	runeText := []rune(text)
+
+	// 遍历文本
+	for _, r := range runeText {
+		current = a.findNextState(current, r)
+
+		// 使用预计算的输出集合
+		for _, index := range current.output {
+			result = append(result, a.words[index])
+		}
+	}
+
+	return result
+}
+
+// match 根据输出集合中的编号和结束位置构造匹配结果
+func (a *ACTree) match(index, end, runeEnd int) Match {
+	if index < 0 {
+		e := a.forms[formID(index)]
+		return Match{
+			Index:     e.index,
+			Pattern:   a.words[e.index],
+			Start:     end - len(e.text),
+			End:       end,
+			RuneStart: runeEnd - e.length,
+			RuneEnd:   runeEnd,
+			Form:      e.form,
+		}
+	}
+	return Match{
+		Index:     index,
+		Pattern:   a.words[index],
+		Start:     end - len(a.words[index]),
+		End:       end,
+		RuneStart: runeEnd - a.lengths[index],
+		RuneEnd:   runeEnd,
+	}
+}
+
+// FindFirst 返回文本中最先出现的匹配
+func (a *ACTree) FindFirst(text string) (Match, bool) {
+	if a.opts.kind != MatchAll {
+		// 不重叠语义下最先的匹配即结果中的第一个
+		if matches := a.FindAll(text); len(matches) > 0 {
+			return matches[0], true
+		}
+		return Match{}, false
+	}
+	if a.opts.generic() {
+		var best Match
+		found := false
+		a.scanFold(text, nil, func(m Match) bool {
+			// 扫描按结束位置升序进行，只需比较第一个结束位置上的匹配
+			if found && m.End != best.End {
+				return false
+			}
+			if better(m, best, found) {
+				best, found = m, true
+			}
+			return true
+		})
+		return best, found
+	}
+	current := a.root
+	runeEnd := 0
+	for end := 0; end < len(text); {
+		r, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+		runeEnd++
+		current = a.findNextState(current, r)
+		if len(current.output) > 0 {
+			// 输出集合中最后一个词最长
+			index := current.output[len(current.output)-1]
+			return a.match(index, end, runeEnd), true
+		}
+	}
+	return Match{}, false
+}
+
+// Each 按扫描顺序（结束位置升序）对每个匹配调用fn，fn返回false时停止扫描
+// 总是报告所有重叠的匹配，不受匹配语义影响。未启用字符变换和边界要求时扫描过程不分配内存。
+func (a *ACTree) Each(text string, fn func(Match) bool) {
+	a.each(text, nil, fn)
+}
+
+// each 同 Each，check不为nil时在消费位于字节偏移pos的字符之前调用check(pos)，返回false时停止扫描
+func (a *ACTree) each(text string, check func(pos int) bool, fn func(Match) bool) {
+	if a.opts.generic() {
+		a.scanFold(text, check, fn)
+		return
+	}
+	current := a.root
+	runeEnd := 0
+	for end := 0; end < len(text); {
+		if check != nil && !check(end) {
+			return
+		}
+		r, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+		runeEnd++
+		current = a.findNextState(current, r)
+		for _, index := range current.output {
+			if !fn(a.match(index, end, runeEnd)) {
+				return
+			}
+		}
+	}
+}
+
+// Matches 返回按扫描顺序产生所有重叠匹配的迭代器，同 Each
+func (a *ACTree) Matches(text string) iter.Seq[Match] {
+	return func(yield func(Match) bool) {
+		a.Each(text, yield)
+	}
+}
+
+// FindAll 按匹配语义返回文本中的匹配
+func (a *ACTree) FindAll(text string) []Match {
+	return resolveMatches(a.scanMatches(text), a.opts.kind)
+}
+
+// scanMatches 按扫描顺序返回文本中所有重叠的匹配
+func (a *ACTree) scanMatches(text string) []Match {
+	var result []Match
+	a.Each(text, func(m Match) bool {
+		result = append(result, m)
+		return true
+	})
+	return result
+}
+
+// Contains 判断文本中是否包含任一模式串
+func (a *ACTree) Contains(text string) bool {
+	if a.opts.generic() {
+		found := false
+		a.scanFold(text, nil, func(Match) bool {
+			found = true
+			return false
+		})
+		return found
+	}
+	current := a.root
+	for _, r := range text {
+		current = a.findNextState(current, r)
+		if len(current.output) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// scanFold 启用大小写折叠、规范化、边界要求或拼音形式时使用通用扫描路径，见 foldScan.scan
+func (a *ACTree) scanFold(text string, check func(pos int) bool, fn func(Match) bool) {
+	f := a.folder()
+	f.scan(text, check, fn)
+}
+
+// folder 返回通用扫描路径使用的状态转移函数和模式串信息
+func (a *ACTree) folder() foldScan[*node] {
+	return foldScan[*node]{
+		opts:     &a.opts,
+		root:     a.root,
+		next:     a.findNextState,
+		output:   func(n *node) []int { return n.output },
+		patterns: a.words,
+		lengths:  a.lengths,
+		maxLen:   a.maxLen,
+		forms:    a.forms,
+		bounds:   a.bounds,
+	}
+}
@@ -0,0 +1,64 @@
+package matcher
+
+import "slices"
+
+// MatchKind 多模式匹配的语义
+type MatchKind int
+
+const (
+	// MatchAll 报告所有匹配，允许重叠（例如 "ushers" 中的 "she"、"he" 和 "hers"）
+	MatchAll MatchKind = iota
+	// LeftmostFirst 不重叠匹配：总是选择起始位置最靠前的匹配，
+	// 起始位置相同时选择在模式串集合中排在前面的模式串
+	LeftmostFirst
+	// LeftmostLongest 不重叠匹配：总是选择起始位置最靠前的匹配，起始位置相同时选择最长的模式串
+	LeftmostLongest
+)
+
+// String 返回匹配语义的名称
+func (k MatchKind) String() string {
+	switch k {
+	case MatchAll:
+		return "MatchAll"
+	case LeftmostFirst:
+		return "LeftmostFirst"
+	case LeftmostLongest:
+		return "LeftmostLongest"
+	}
+	return "MatchKind(?)"
+}
+
+// resolveMatches 按匹配语义从所有重叠匹配中选出结果，返回的匹配按文本顺序排列
+// MatchAll 时原样排序返回，其他语义会复用matches的存储空间
+func resolveMatches(matches []Match, kind MatchKind) []Match {
+	switch kind {
+	case LeftmostFirst:
+		slices.SortFunc(matches, func(a, b Match) int {
+			if a.Start != b.Start {
+				return a.Start - b.Start
+			}
+			return a.Index - b.Index
+		})
+	case LeftmostLongest:
+		slices.SortFunc(matches, func(a, b Match) int {
+			if a.Start != b.Start {
+				return a.Start - b.Start
+			}
+			return b.End - a.End
+		})
+	default:
+		sortMatches(matches)
+		return matches
+	}
+
+	// 依次选择与已选匹配不重叠的第一个候选
+	result := matches[:0]
+	lastEnd := -1
+	for _, m := range matches {
+		if m.Start >= lastEnd {
+			result = append(result, m)
+			lastEnd = m.End
+		}
+	}
+	return result
+}
@@ -0,0 +1,144 @@
+package matcher
+
+import (
+	"reflect"
+	"testing"
+)
+
+// 构建所有支持 MatchKind 的匹配器
+func buildKindMatchers(t *testing.T, patterns []string, kind MatchKind) map[string]Matcher {
+	matchers := map[string]Matcher{
+		"AC":          NewAC(WithMatchKind(kind)),
+		"ACTree":      NewAc(WithMatchKind(kind)),
+		"ByteAC":      NewByteAC(WithMatchKind(kind)),
+		"DoubleArray": NewDoubleArray(WithMatchKind(kind)),
+		"Trie":        NewTrie(WithMatchKind(kind)),
+		"BruteForce":  NewBruteForce(WithMatchKind(kind)),
+		"KMP":         NewKMP(WithMatchKind(kind)),
+	}
+	for name, m := range matchers {
+		if err := m.Build(patterns); err != nil {
+			t.Fatalf("%s Build() error = %v", name, err)
+		}
+	}
+	return matchers
+}
+
+// 测试不同匹配语义下的结果
+func TestMatchKind(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		patterns []string
+		kind     MatchKind
+		want     []string // 按文本顺序排列的匹配文本
+		wantRune []int    // 对应的rune起始位置
+	}{
+		{
+			name:     "经典示例_最左最长",
+			text:     "ushers",
+			patterns: []string{"he", "she", "his", "hers"},
+			kind:     LeftmostLongest,
+			want:     []string{"she"},
+			wantRune: []int{1},
+		},
+		{
+			name:     "经典示例_最左优先",
+			text:     "ushers",
+			patterns: []string{"hers", "he", "she"},
+			kind:     LeftmostFirst,
+			want:     []string{"she"},
+			wantRune: []int{1},
+		},
+		{
+			name:     "模式串前缀重叠_所有匹配",
+			text:     "测试测试测和",
+			patterns: []string{"测试", "测试测", "测试测试", "测试测试测"},
+			kind:     MatchAll,
+			want:     []string{"测试", "测试测", "测试测试", "测试测试测", "测试", "测试测"},
+			wantRune: []int{0, 0, 0, 0, 2, 2},
+		},
+		{
+			name:     "模式串前缀重叠_最左最长",
+			text:     generateRepeatedText("测试测试测和测试测试测", 2),
+			patterns: []string{"测试", "测试测", "测试测试", "测试测试测"},
+			kind:     LeftmostLongest,
+			want:     []string{"测试测试测", "测试测试测", "测试测试测", "测试测试测"},
+			wantRune: []int{0, 6, 11, 17},
+		},
+		{
+			name:     "模式串前缀重叠_最左优先",
+			text:     generateRepeatedText("测试测试测和测试测试测", 2),
+			patterns: []string{"测试", "测试测", "测试测试", "测试测试测"},
+			kind:     LeftmostFirst,
+			want:     []string{"测试", "测试", "测试", "测试", "测试", "测试", "测试", "测试"},
+			wantRune: []int{0, 2, 6, 8, 11, 13, 17, 19},
+		},
+		{
+			name:     "模式串后缀重叠_最左最长",
+			text:     "测试测试测和测试测试测试",
+			patterns: []string{"试测", "试测试", "试测试测", "试测试测试"},
+			kind:     LeftmostLongest,
+			want:     []string{"试测试测", "试测试测试"},
+			wantRune: []int{1, 7},
+		},
+		{
+			name:     "模式串后缀重叠_最左优先",
+			text:     "测试测试测和测试测试测试",
+			patterns: []string{"试测", "试测试", "试测试测", "试测试测试"},
+			kind:     LeftmostFirst,
+			want:     []string{"试测", "试测", "试测", "试测"},
+			wantRune: []int{1, 3, 7, 9},
+		},
+		{
+			name:     "模式串后缀重叠_最左优先长模式在前",
+			text:     "测试测试测和测试测试测试",
+			patterns: []string{"试测试测试", "试测试", "试测"},
+			kind:     LeftmostFirst,
+			want:     []string{"试测试", "试测试测试"},
+			wantRune: []int{1, 7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, m := range buildKindMatchers(t, tt.patterns, tt.kind) {
+				matches := m.FindAll(tt.text)
+				var got []string
+				var gotRune []int
+				for _, match := range matches {
+					got = append(got, tt.text[match.Start:match.End])
+					gotRune = append(gotRune, match.RuneStart)
+				}
+				if !reflect.DeepEqual(got, tt.want) || !reflect.DeepEqual(gotRune, tt.wantRune) {
+					t.Errorf("%s FindAll() = %v %v, want %v %v", name, got, gotRune, tt.want, tt.wantRune)
+				}
+
+				first, found := m.FindFirst(tt.text)
+				if !found || first != matches[0] {
+					t.Errorf("%s FindFirst() = %+v, %v, want %+v", name, first, found, matches[0])
+				}
+			}
+
+			// Scan 与 FindAll 选择的匹配一致
+			ac := NewAc(WithMatchKind(tt.kind))
+			ac.Build(tt.patterns)
+			if got := ac.Scan(tt.text); len(got) != len(tt.want) {
+				t.Errorf("ACTree.Scan() = %v, want %d matches", got, len(tt.want))
+			}
+
+			// Trie 的 Search 和 CountMatches 与 FindAll 选择的匹配一致
+			trie := BuildTrie(tt.patterns, WithMatchKind(tt.kind))
+			positions, counts := 0, 0
+			for _, pos := range trie.Search(tt.text) {
+				positions += len(pos)
+			}
+			for _, n := range trie.CountMatches(tt.text) {
+				counts += n
+			}
+			if positions != len(tt.want) || counts != len(tt.want) {
+				t.Errorf("Trie Search() = %d, CountMatches() = %d, want %d matches", positions, counts, len(tt.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Package matcher 提供字符串匹配算法的实现，包括暴力匹配、KMP、Trie树和AC自动机。
+//
+// 所有算法都实现了 Matcher 接口，匹配结果 Match 同时给出字节偏移和rune偏移，
+// 既可以直接切片原始字符串，也方便处理中文等多字节字符。
+package matcher
+
+import (
+	"slices"
+	"unicode/utf8"
+)
+
+// Match 一次匹配的结果
+type Match struct {
+	Index     int        // 模式串在构建时传入的模式串集合中的下标
+	Pattern   string     // 匹配到的模式串
+	Start     int        // 匹配起始位置（字节偏移）
+	End       int        // 匹配结束位置（字节偏移，不包含），text[Start:End] 即匹配文本
+	RuneStart int        // 匹配起始位置（rune偏移）
+	RuneEnd   int        // 匹配结束位置（rune偏移，不包含）
+	Form      PinyinForm // 匹配到的模式串形式，匹配原始模式串时为 PinyinNone
+}
+
+// Matcher 多模式串匹配器的公共接口
+type Matcher interface {
+	// Build 使用模式串集合构建匹配器，空模式串会被忽略，重复的模式串只保留第一次出现的下标
+	Build(patterns []string) error
+	// FindFirst 返回文本中最先出现的匹配（结束位置最靠前，相同时取最长的模式串），
+	// 匹配语义为 LeftmostFirst 或 LeftmostLongest 时返回 FindAll 的第一个匹配，
+	// 未找到时第二个返回值为false
+	FindFirst(text string) (Match, bool)
+	// FindAll 返回文本中所有的匹配，按起始位置升序排列，起始位置相同时短的在前
+	// 默认允许重叠，支持 MatchKind 的AC自动机可以选择不重叠的匹配语义
+	FindAll(text string) []Match
+	// Contains 判断文本中是否包含任一模式串
+	Contains(text string) bool
+}
+
+// 编译期检查各实现是否满足 Matcher 接口
+var (
+	_ Matcher = (*BruteForce)(nil)
+	_ Matcher = (*KMP)(nil)
+	_ Matcher = (*Trie)(nil)
+	_ Matcher = (*ACTree)(nil)
+	_ Matcher = (*AC)(nil)
+	_ Matcher = (*ByteAC)(nil)
+	_ Matcher = (*DoubleArray)(nil)
+)
+
+// better 判断候选匹配是否优于当前最先匹配
+// 结束位置越靠前越优，结束位置相同时取更长（起始位置更靠前）的匹配
+func better(m, best Match, found bool) bool {
+	if !found {
+		return true
+	}
+	if m.End != best.End {
+		return m.End < best.End
+	}
+	return m.Start < best.Start
+}
+
+// compareMatch 按文本顺序比较两个匹配：起始位置升序，起始位置相同时结束位置升序
+func compareMatch(a, b Match) int {
+	if a.Start != b.Start {
+		return a.Start - b.Start
+	}
+	return a.End - b.End
+}
+
+// sortMatches 将匹配结果按文本顺序排序
+func sortMatches(matches []Match) {
+	slices.SortFunc(matches, compareMatch)
+}
+
+// runeOffsets 返回文本中每个rune的起始字节偏移，最后一项为文本的字节长度
+// n为文本的rune数量
+func runeOffsets(text string, n int) []int {
+	offsets := make([]int, 0, n+1)
+	for i := 0; i < len(text); {
+		offsets = append(offsets, i)
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+	}
+	return append(offsets, len(text))
+}
@@ -0,0 +1,96 @@
+package matcher
+
+import (
+	"reflect"
+	"testing"
+)
+
+// 构建所有 Matcher 实现
+func buildMatchers(t *testing.T, patterns []string) map[string]Matcher {
+	matchers := map[string]Matcher{
+		"BF":          NewBruteForce(),
+		"KMP":         NewKMP(),
+		"Trie":        NewTrie(),
+		"AC":          NewAC(),
+		"ACTree":      NewAc(),
+		"ByteAC":      NewByteAC(),
+		"DoubleArray": NewDoubleArray(),
+	}
+	for name, m := range matchers {
+		if err := m.Build(patterns); err != nil {
+			t.Fatalf("%s Build() error = %v", name, err)
+		}
+	}
+	return matchers
+}
+
+// 测试所有 Matcher 实现结果一致
+func TestMatcherInterface(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		patterns  []string
+		wantFirst Match
+		wantAll   []Match
+	}{
+		{
+			name:      "经典示例",
+			text:      "ushers",
+			patterns:  []string{"he", "she", "his", "hers"},
+			wantFirst: Match{Index: 1, Pattern: "she", Start: 1, End: 4, RuneStart: 1, RuneEnd: 4},
+			wantAll: []Match{
+				{Index: 1, Pattern: "she", Start: 1, End: 4, RuneStart: 1, RuneEnd: 4},
+				{Index: 0, Pattern: "he", Start: 2, End: 4, RuneStart: 2, RuneEnd: 4},
+				{Index: 3, Pattern: "hers", Start: 2, End: 6, RuneStart: 2, RuneEnd: 6},
+			},
+		},
+		{
+			name:      "中文重叠",
+			text:      "测试测试测",
+			patterns:  []string{"测试", "试测", "测", "测试"},
+			wantFirst: Match{Index: 2, Pattern: "测", Start: 0, End: 3, RuneStart: 0, RuneEnd: 1},
+			wantAll: []Match{
+				{Index: 2, Pattern: "测", Start: 0, End: 3, RuneStart: 0, RuneEnd: 1},
+				{Index: 0, Pattern: "测试", Start: 0, End: 6, RuneStart: 0, RuneEnd: 2},
+				{Index: 1, Pattern: "试测", Start: 3, End: 9, RuneStart: 1, RuneEnd: 3},
+				{Index: 2, Pattern: "测", Start: 6, End: 9, RuneStart: 2, RuneEnd: 3},
+				{Index: 0, Pattern: "测试", Start: 6, End: 12, RuneStart: 2, RuneEnd: 4},
+				{Index: 1, Pattern: "试测", Start: 9, End: 15, RuneStart: 3, RuneEnd: 5},
+				{Index: 2, Pattern: "测", Start: 12, End: 15, RuneStart: 4, RuneEnd: 5},
+			},
+		},
+		{
+			name:      "表情符号",
+			text:      "你好👋世界🌍",
+			patterns:  []string{"世界🌍", "👋世"},
+			wantFirst: Match{Index: 1, Pattern: "👋世", Start: 6, End: 13, RuneStart: 2, RuneEnd: 4},
+			wantAll: []Match{
+				{Index: 1, Pattern: "👋世", Start: 6, End: 13, RuneStart: 2, RuneEnd: 4},
+				{Index: 0, Pattern: "世界🌍", Start: 10, End: 20, RuneStart: 3, RuneEnd: 6},
+			},
+		},
+		{
+			name:     "无匹配",
+			text:     "Hello, World!",
+			patterns: []string{"Python", "", "你好"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wantFound := tt.wantAll != nil
+			for name, m := range buildMatchers(t, tt.patterns) {
+				first, found := m.FindFirst(tt.text)
+				if found != wantFound || first != tt.wantFirst {
+					t.Errorf("%s FindFirst() = %+v, %v, want %+v, %v", name, first, found, tt.wantFirst, wantFound)
+				}
+				if got := m.FindAll(tt.text); !reflect.DeepEqual(got, tt.wantAll) {
+					t.Errorf("%s FindAll() = %+v, want %+v", name, got, tt.wantAll)
+				}
+				if got := m.Contains(tt.text); got != wantFound {
+					t.Errorf("%s Contains() = %v, want %v", name, got, wantFound)
+				}
+			}
+		})
+	}
+}
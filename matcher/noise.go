@@ -0,0 +1,37 @@
+package matcher
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WithIgnorable 设置匹配时跳过的可忽略字符，用于识别 "敏*感*词"、"敏 感 词" 这类插入了干扰字符的文本
+//
+// ignore 返回true的字符在构建和搜索时都会被跳过，匹配不会从可忽略字符开始或结束，
+// 但报告的匹配范围包含中间被跳过的字符。maxGap 为两个有效字符之间最多允许跳过的连续可忽略字符数，
+// 超过时匹配中断，小于0表示不限制。可忽略字符在规范化和等价字符替换之后判断。
+// BruteForce、KMP、Trie、ACTree 和 AC 支持该配置。
+func WithIgnorable(ignore func(rune) bool, maxGap int) Option {
+	return func(o *options) {
+		o.ignore = ignore
+		o.maxGap = maxGap
+	}
+}
+
+// gapExceeded 判断连续跳过gap个可忽略字符是否超过了上限
+func (o *options) gapExceeded(gap int) bool {
+	return o.maxGap >= 0 && gap > o.maxGap
+}
+
+// IsNoise 判断字符是否为常见的干扰字符：空白、标点、符号（包括emoji）、零宽字符等格式字符以及变体选择符
+func IsNoise(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) ||
+		unicode.In(r, unicode.Cf, unicode.Variation_Selector)
+}
+
+// IgnoreRunes 返回把chars中的字符作为可忽略字符的判断函数
+func IgnoreRunes(chars string) func(rune) bool {
+	return func(r rune) bool {
+		return strings.ContainsRune(chars, r)
+	}
+}
@@ -0,0 +1,104 @@
+package matcher
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIgnorable(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		text     string
+		patterns []string
+		want     []Match
+	}{
+		{
+			name:     "星号分隔",
+			opts:     []Option{WithIgnorable(IgnoreRunes("*"), -1)},
+			text:     "这是敏*感*词。",
+			patterns: []string{"敏感词"},
+			want: []Match{
+				{Index: 0, Pattern: "敏感词", Start: 6, End: 17, RuneStart: 2, RuneEnd: 7},
+			},
+		},
+		{
+			name:     "零宽字符和emoji",
+			opts:     []Option{WithIgnorable(IsNoise, -1)},
+			text:     "敏​感👍🏻词 敏 感 词",
+			patterns: []string{"敏感词"},
+			want: []Match{
+				{Index: 0, Pattern: "敏感词", Start: 0, End: 20, RuneStart: 0, RuneEnd: 6},
+				{Index: 0, Pattern: "敏感词", Start: 21, End: 32, RuneStart: 7, RuneEnd: 12},
+			},
+		},
+		{
+			name:     "最大间隔",
+			opts:     []Option{WithIgnorable(IsNoise, 2)},
+			text:     "敏**感词 敏***感词 感-词",
+			patterns: []string{"敏感词", "感词"},
+			want: []Match{
+				{Index: 0, Pattern: "敏感词", Start: 0, End: 11, RuneStart: 0, RuneEnd: 5},
+				{Index: 1, Pattern: "感词", Start: 5, End: 11, RuneStart: 3, RuneEnd: 5},
+				{Index: 1, Pattern: "感词", Start: 18, End: 24, RuneStart: 10, RuneEnd: 12},
+				{Index: 1, Pattern: "感词", Start: 25, End: 32, RuneStart: 13, RuneEnd: 16},
+			},
+		},
+		{
+			name:     "模式串中的可忽略字符",
+			opts:     []Option{WithIgnorable(IsNoise, 0)},
+			text:     "敏感词",
+			patterns: []string{"敏 感-词"},
+			want: []Match{
+				{Index: 0, Pattern: "敏 感-词", Start: 0, End: 9, RuneStart: 0, RuneEnd: 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ref := foldReference(tt.patterns, tt.text, tt.opts...); !reflect.DeepEqual(ref, tt.want) {
+				t.Fatalf("reference = %+v, want %+v", ref, tt.want)
+			}
+			for name, m := range buildFoldMatchers(t, tt.patterns, tt.opts...) {
+				if got := m.FindAll(tt.text); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("%s FindAll() = %+v, want %+v", name, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// 测试各实现跳过可忽略字符的结果与参考实现一致
+func TestIgnorableConsistency(t *testing.T) {
+	// "*" 和 ".." 变换后为空，所有实现都不应产生匹配
+	patterns := []string{"敏感词", "感", "ab", "a b", "b*c", "你好", "*", ".."}
+	texts := []string{
+		"敏.感.词 敏..感词 敏...感..词",
+		"a  b * c a*b**c",
+		"*你*好*  你 \t 好",
+		"a ​ b、a​​​b",
+	}
+	for _, maxGap := range []int{-1, 0, 1, 2} {
+		for _, norm := range []Normalization{0, NormalizeSpace} {
+			opts := []Option{WithIgnorable(IgnoreRunes("*.、​"), maxGap), WithNormalization(norm)}
+			matchers := buildFoldMatchers(t, patterns, opts...)
+			for _, text := range texts {
+				want := foldReference(patterns, text, opts...)
+				for name, m := range matchers {
+					if got := m.FindAll(text); !reflect.DeepEqual(got, want) {
+						t.Errorf("maxGap=%d %v %s FindAll(%q) = %+v, want %+v", maxGap, norm, name, text, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestIgnorableMask(t *testing.T) {
+	a := NewAc(WithIgnorable(IsNoise, 3))
+	a.Build([]string{"敏感词"})
+	if got, want := a.Mask("敏 感 词和敏-感-词", '*'), "*****和*****"; got != want {
+		t.Errorf("Mask() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,163 @@
+package matcher
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Normalization 匹配前对文本进行的规范化，可以按位组合
+//
+// 与大小写折叠一样，模式串在构建时规范化，文本在搜索时逐字符规范化，
+// 匹配结果中的位置仍然是原文中的偏移。同时启用大小写折叠时先规范化再折叠，
+// 例如 "Ｈｅｌｌｏ" 规范化为 "Hello" 后再折叠为 "hello"。
+type Normalization uint8
+
+const (
+	// NormalizeWidth 全角ASCII字符（U+FF01～U+FF5E）转换为对应的半角字符，全角空格转换为空格
+	NormalizeWidth Normalization = 1 << iota
+	// NormalizePunct 中文标点转换为对应的ASCII标点，例如 "，" 与 ","、"！" 与 "!"、"【" 与 "[" 等价
+	NormalizePunct
+	// NormalizeSpace 连续的空白字符合并为一个空格，例如 "你好 \t\n世界" 与 "你好 世界" 等价
+	NormalizeSpace
+
+	// NormalizeAll 启用所有规范化
+	NormalizeAll = NormalizeWidth | NormalizePunct | NormalizeSpace
+)
+
+// String 返回规范化方式的名称，多个方式以 "|" 连接
+func (n Normalization) String() string {
+	if n == 0 {
+		return "NormalizeNone"
+	}
+	var names []string
+	for _, item := range []struct {
+		flag Normalization
+		name string
+	}{
+		{NormalizeWidth, "NormalizeWidth"},
+		{NormalizePunct, "NormalizePunct"},
+		{NormalizeSpace, "NormalizeSpace"},
+	} {
+		if n&item.flag != 0 {
+			names = append(names, item.name)
+			n &^= item.flag
+		}
+	}
+	if n != 0 {
+		names = append(names, "Normalization(?)")
+	}
+	return strings.Join(names, "|")
+}
+
+// cjkPuncts 中文标点到ASCII标点的映射（全角ASCII标点由 fullWidthOffset 统一处理）
+var cjkPuncts = map[rune]rune{
+	'、': ',',
+	'。': '.',
+	'〈': '<',
+	'〉': '>',
+	'《': '<',
+	'》': '>',
+	'「': '"',
+	'」': '"',
+	'『': '"',
+	'』': '"',
+	'【': '[',
+	'】': ']',
+	'〔': '[',
+	'〕': ']',
+	'“': '"',
+	'”': '"',
+	'‘': '\'',
+	'’': '\'',
+	'—': '-',
+	'〜': '~',
+}
+
+const (
+	// fullWidthOffset 全角ASCII字符与对应半角字符的码点差
+	fullWidthOffset = 0xFF01 - '!'
+	// ideographicSpace 全角空格
+	ideographicSpace = '　'
+)
+
+// WithNormalization 设置文本规范化方式，默认不进行规范化
+// BruteForce、KMP、Trie、ACTree 和 AC 支持该配置，规范化后相同的模式串视为重复的模式串
+func WithNormalization(norm Normalization) Option {
+	return func(o *options) {
+		o.norm = norm
+	}
+}
+
+// isFullWidth 判断字符是否为全角ASCII字符
+func isFullWidth(r rune) bool {
+	return 0xFF01 <= r && r <= 0xFF5E
+}
+
+// normalize 对单个字符进行宽度和标点规范化
+func (n Normalization) normalize(r rune) rune {
+	if r < 0x2000 {
+		return r
+	}
+	if n&NormalizeWidth != 0 {
+		if isFullWidth(r) {
+			return r - fullWidthOffset
+		}
+		if r == ideographicSpace {
+			return ' '
+		}
+	}
+	if n&NormalizePunct != 0 {
+		// 全角标点，不包括全角字母和数字
+		if isFullWidth(r) {
+			if half := r - fullWidthOffset; !unicode.IsLetter(half) && !unicode.IsDigit(half) {
+				return half
+			}
+		}
+		if p, ok := cjkPuncts[r]; ok {
+			return p
+		}
+	}
+	return r
+}
+
+// transformed 判断匹配前是否需要对字符进行变换（规范化、等价字符替换或大小写折叠）
+func (o *options) transformed() bool {
+	return o.fold != NoCaseFold || o.norm != 0 || o.variants != nil || o.ignore != nil
+}
+
+// foldRune 依次对字符进行规范化、等价字符替换和大小写折叠，结果写入dst并返回变换后的字符数
+// space表示前一个有效的原文字符是否为空白，合并空白时连续空白中除第一个以外都变换为空；
+// 可忽略字符变换为空并且第二个返回值为true
+func (o *options) foldRune(r rune, space bool, dst *[maxFoldRunes]rune) (int, bool) {
+	r = o.norm.normalize(r)
+	if o.variants != nil {
+		r = o.variants.Canonical(r)
+	}
+	if o.ignore != nil && o.ignore(r) {
+		return 0, true
+	}
+	if o.norm&NormalizeSpace != 0 && unicode.IsSpace(r) {
+		if space {
+			return 0, false
+		}
+		dst[0] = ' '
+		return 1, false
+	}
+	return o.fold.foldRune(r, dst), false
+}
+
+// foldString 返回字符串变换后的字符序列
+func (o *options) foldString(s string) []rune {
+	folded := make([]rune, 0, len(s))
+	var buf [maxFoldRunes]rune
+	space := false
+	for _, r := range s {
+		n, ignored := o.foldRune(r, space, &buf)
+		if ignored {
+			continue
+		}
+		folded = append(folded, buf[:n]...)
+		space = unicode.IsSpace(r)
+	}
+	return folded
+}
@@ -0,0 +1,119 @@
+package matcher
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalization(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		text     string
+		patterns []string
+		want     []Match
+	}{
+		{
+			name:     "全角字母",
+			opts:     []Option{WithNormalization(NormalizeWidth)},
+			text:     "说Ｈｅｌｌｏ，",
+			patterns: []string{"Hello,", "Ｈｅ"},
+			want: []Match{
+				{Index: 1, Pattern: "Ｈｅ", Start: 3, End: 9, RuneStart: 1, RuneEnd: 3},
+				{Index: 0, Pattern: "Hello,", Start: 3, End: 21, RuneStart: 1, RuneEnd: 7},
+			},
+		},
+		{
+			name:     "中文标点",
+			opts:     []Option{WithNormalization(NormalizePunct)},
+			text:     "你好，世界！【注意】Ｈｉ！",
+			patterns: []string{"你好,世界!", "[注意]", "Hi!"},
+			want: []Match{
+				{Index: 0, Pattern: "你好,世界!", Start: 0, End: 18, RuneStart: 0, RuneEnd: 6},
+				{Index: 1, Pattern: "[注意]", Start: 18, End: 30, RuneStart: 6, RuneEnd: 10},
+			},
+		},
+		{
+			name:     "合并空白",
+			opts:     []Option{WithNormalization(NormalizeSpace)},
+			text:     "你好 \t\n世界　你好世界",
+			patterns: []string{"你好  世界", "世界 ", "好世"},
+			want: []Match{
+				{Index: 0, Pattern: "你好  世界", Start: 0, End: 15, RuneStart: 0, RuneEnd: 7},
+				{Index: 1, Pattern: "世界 ", Start: 9, End: 18, RuneStart: 5, RuneEnd: 8},
+				{Index: 2, Pattern: "好世", Start: 21, End: 27, RuneStart: 9, RuneEnd: 11},
+			},
+		},
+		{
+			name:     "规范化后折叠",
+			opts:     []Option{WithNormalization(NormalizeAll), WithCaseFold(SimpleCaseFold)},
+			text:     "ＨＥＬＬＯ，　ＷＯＲＬＤ！",
+			patterns: []string{"hello, world!"},
+			want: []Match{
+				{Index: 0, Pattern: "hello, world!", Start: 0, End: 39, RuneStart: 0, RuneEnd: 13},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ref := foldReference(tt.patterns, tt.text, tt.opts...); !reflect.DeepEqual(ref, tt.want) {
+				t.Fatalf("reference = %+v, want %+v", ref, tt.want)
+			}
+			for name, m := range buildFoldMatchers(t, tt.patterns, tt.opts...) {
+				if got := m.FindAll(tt.text); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("%s FindAll() = %+v, want %+v", name, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// 测试各实现的规范化匹配与参考实现一致
+func TestNormalizationConsistency(t *testing.T) {
+	patterns := []string{"a b", " ", "你好,", "，世界", "Hello", "[ok]", " x ", "！"}
+	texts := []string{
+		"  a  \t b  ",
+		"你好，世界！　你好, 世界",
+		"ＨＥＬＬＯ Ｈｅｌｌｏ hello",
+		"【ＯＫ】 [ok] 〔ok〕",
+		"x 　x \n\n x",
+	}
+	for _, norm := range []Normalization{NormalizeWidth, NormalizePunct, NormalizeSpace, NormalizeAll} {
+		opts := []Option{WithNormalization(norm), WithCaseFold(SimpleCaseFold)}
+		matchers := buildFoldMatchers(t, patterns, opts...)
+		for _, text := range texts {
+			want := foldReference(patterns, text, opts...)
+			for name, m := range matchers {
+				if got := m.FindAll(text); !reflect.DeepEqual(got, want) {
+					t.Errorf("%v %s FindAll(%q) = %+v, want %+v", norm, name, text, got, want)
+				}
+				if got := m.Contains(text); got != (len(want) > 0) {
+					t.Errorf("%v %s Contains(%q) = %v", norm, name, text, got)
+				}
+			}
+		}
+	}
+}
+
+func TestNormalizationMask(t *testing.T) {
+	a := NewAc(WithNormalization(NormalizeWidth), WithCaseFold(SimpleCaseFold))
+	a.Build([]string{"bad"})
+	if got, want := a.Mask("敏感词：ＢＡＤ word, Bad!", '*'), "敏感词：*** word, ***!"; got != want {
+		t.Errorf("Mask() = %q, want %q", got, want)
+	}
+}
+
+func TestNormalizationString(t *testing.T) {
+	tests := map[Normalization]string{
+		0:                               "NormalizeNone",
+		NormalizeWidth:                  "NormalizeWidth",
+		NormalizeWidth | NormalizeSpace: "NormalizeWidth|NormalizeSpace",
+		NormalizeAll | 0x80:             "NormalizeWidth|NormalizePunct|NormalizeSpace|Normalization(?)",
+	}
+	for norm, want := range tests {
+		if got := norm.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
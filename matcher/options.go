@@ -0,0 +1,67 @@
+package matcher
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupportedOption 匹配器不支持构建时传入的配置
+var ErrUnsupportedOption = errors.New("matcher: unsupported option")
+
+// Option 构建匹配器时的可选配置
+type Option func(*options)
+
+// options 匹配器的配置项
+type options struct {
+	kind     MatchKind       // 匹配语义
+	fold     CaseFold        // 大小写折叠方式
+	norm     Normalization   // 文本规范化方式
+	variants *VariantTable   // 字符等价表
+	ignore   func(rune) bool // 匹配时跳过的可忽略字符
+	maxGap   int             // 两个有效字符之间最多跳过的可忽略字符数，小于0表示不限制
+
+	pinyin      PinyinForm   // 额外生成的拼音形式
+	pinyinTable *PinyinTable // 生成拼音形式使用的拼音表
+
+	boundary func(index int, pattern string) Boundary // 模式串的边界要求
+}
+
+// newOptions 应用所有配置项并返回最终配置
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// onlyMatchKind 检查配置中除匹配语义外没有其他配置，name为匹配器名称
+func (o *options) onlyMatchKind(name string) error {
+	if o.generic() || o.pinyin != PinyinNone {
+		return fmt.Errorf("%w: %s only supports WithMatchKind", ErrUnsupportedOption, name)
+	}
+	return nil
+}
+
+// noPinyin 检查配置中没有拼音形式，name为匹配器名称
+func (o *options) noPinyin(name string) error {
+	if o.pinyin != PinyinNone {
+		return fmt.Errorf("%w: %s does not support WithPinyin", ErrUnsupportedOption, name)
+	}
+	return nil
+}
+
+// noBoundary 检查配置中没有边界要求，name为匹配器名称
+func (o *options) noBoundary(name string) error {
+	if o.boundary != nil {
+		return fmt.Errorf("%w: %s does not support WithBoundary", ErrUnsupportedOption, name)
+	}
+	return nil
+}
+
+// WithMatchKind 设置匹配语义，默认为 MatchAll
+func WithMatchKind(kind MatchKind) Option {
+	return func(o *options) {
+		o.kind = kind
+	}
+}
@@ -0,0 +1,105 @@
+package matcher
+
+import (
+	"runtime"
+	"sync"
+	"unicode/utf8"
+)
+
+// minChunkSize 并行搜索时每个分块的最小字节数，更短的文本直接顺序搜索
+const minChunkSize = 64 << 10
+
+// chunkResult 一个分块的搜索结果
+type chunkResult struct {
+	ids   []int // 匹配到的模式串下标，按结束位置排列
+	pos   []int // 匹配的起始位置（rune），相对于分块开头，可以为负
+	runes int   // 分块的字符数
+}
+
+// ParallelSearch 将文本分块后使用workers个goroutine并行搜索，结果与 Search 完全相同
+// workers不大于0时使用 runtime.GOMAXPROCS(0)。
+//
+// 每个分块从开头之前的 maxLen 个字符开始扫描以恢复自动机状态，只保留结束位置在分块内的匹配，
+// 因此跨越分块边界的匹配只由结束位置所在的分块报告一次。
+// 匹配语义不是 MatchAll、启用了字符变换、边界要求或拼音形式时匹配结果依赖于整个文本，退化为顺序搜索。
+func (ac *AC) ParallelSearch(text string, workers int) map[string][]int {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	// 每个worker分配多个分块，平衡不同分块匹配数量的差异
+	return ac.searchChunks(text, workers, max(minChunkSize, len(text)/(workers*4)+1))
+}
+
+// searchChunks 按chunkSize字节分块并行搜索
+func (ac *AC) searchChunks(text string, workers, chunkSize int) map[string][]int {
+	if workers == 1 || len(text) <= chunkSize || ac.opts.kind != MatchAll || ac.opts.generic() || len(ac.forms) > 0 {
+		return ac.Search(text)
+	}
+
+	// 分块边界必须落在字符的开头（不能是UTF-8续字节），与顺序解码的结果一致
+	cuts := []int{0}
+	for start := chunkSize; start < len(text); start += chunkSize {
+		for start < len(text) && !utf8.RuneStart(text[start]) {
+			start++
+		}
+		if start > cuts[len(cuts)-1] && start < len(text) {
+			cuts = append(cuts, start)
+		}
+	}
+	cuts = append(cuts, len(text))
+
+	results := make([]chunkResult, len(cuts)-1)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(results)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = ac.searchChunk(text, cuts[i], cuts[i+1])
+			}
+		}()
+	}
+	for i := range results {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	// 按分块顺序合并，每个模式串的位置与顺序搜索一样按结束位置排列
+	result := make(map[string][]int)
+	offset := 0
+	for _, r := range results {
+		for i, index := range r.ids {
+			pattern := ac.patterns[index]
+			result[pattern] = append(result[pattern], offset+r.pos[i])
+		}
+		offset += r.runes
+	}
+	return result
+}
+
+// searchChunk 搜索结束位置在text[start:end)内的匹配
+func (ac *AC) searchChunk(text string, start, end int) chunkResult {
+	// 自动机状态只取决于最近的 maxLen 个字符，从分块开头之前的 maxLen 个字符开始扫描即可恢复状态
+	warm := start
+	for i := 0; i < ac.maxLen && warm > 0; i++ {
+		_, size := utf8.DecodeLastRuneInString(text[:warm])
+		warm -= size
+	}
+	current := ac.root
+	for _, r := range text[warm:start] {
+		current = ac.findNextState(current, r)
+	}
+
+	var result chunkResult
+	for _, r := range text[start:end] {
+		current = ac.findNextState(current, r)
+		for _, index := range current.output {
+			result.ids = append(result.ids, index)
+			result.pos = append(result.pos, result.runes-ac.lengths[index]+1)
+		}
+		result.runes++
+	}
+	return result
+}
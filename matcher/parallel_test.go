@@ -0,0 +1,64 @@
+package matcher
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// 随机文本按不同的分块大小并行搜索，结果必须与顺序搜索完全相同
+func TestParallelSearchDifferential(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	// 字母表包含非法的UTF-8字节，检查分块边界与顺序解码一致
+	for _, alphabet := range [][]string{
+		{"a", "b"},
+		{"a", "b", "c", "\xff", "\xe4", "\xb8", "中"},
+		strings.Split(cjkAlphabet(8)+"ab👋", ""),
+	} {
+		randomString := func(n int) string {
+			var sb strings.Builder
+			for range n {
+				sb.WriteString(alphabet[rng.Intn(len(alphabet))])
+			}
+			return sb.String()
+		}
+		for round := 0; round < 50; round++ {
+			var patterns []string
+			for range 1 + rng.Intn(10) {
+				patterns = append(patterns, randomString(1+rng.Intn(6)))
+			}
+			ac, err := BuildAC(patterns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			text := randomString(rng.Intn(300))
+			want := ac.Search(text)
+			for _, chunkSize := range []int{1, 2, 3, 7, 64} {
+				if got := ac.searchChunks(text, 4, chunkSize); !reflect.DeepEqual(got, want) {
+					t.Fatalf("patterns %q, text %q, chunk %d:\ngot  %v\nwant %v", patterns, text, chunkSize, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestParallelSearch(t *testing.T) {
+	text := strings.Repeat("ushers 敏感词 his hers ", 20000)
+	for _, opts := range [][]Option{
+		nil,
+		{WithCaseFold(SimpleCaseFold)},
+		{WithMatchKind(LeftmostLongest)},
+	} {
+		ac, err := BuildAC([]string{"he", "she", "his", "hers", "敏感词 h"}, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := ac.Search(text)
+		for _, workers := range []int{0, 1, 3} {
+			if got := ac.ParallelSearch(text, workers); !reflect.DeepEqual(got, want) {
+				t.Errorf("ParallelSearch(workers=%d) differs from Search", workers)
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package matcher
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDuplicatePattern 构建时出现了重复的模式串
+var ErrDuplicatePattern = errors.New("matcher: duplicate pattern")
+
+// Entry 带附加数据的模式串，例如敏感词的编号、分类和等级
+type Entry[T any] struct {
+	Pattern string
+	Payload T
+}
+
+// PayloadMatch 带附加数据的匹配结果
+type PayloadMatch[T any] struct {
+	Match
+	Payload T // 模式串的附加数据
+}
+
+// MergeFunc 合并重复模式串的附加数据，old为已有的附加数据，later为后出现的附加数据
+// 返回错误时构建失败。模式串按配置变换后相同即视为重复
+type MergeFunc[T any] func(pattern string, old, later T) (T, error)
+
+// KeepFirst 保留第一次出现的附加数据，与 Build 的去重规则一致
+func KeepFirst[T any](_ string, old, _ T) (T, error) {
+	return old, nil
+}
+
+// KeepLast 保留最后一次出现的附加数据
+func KeepLast[T any](_ string, _, later T) (T, error) {
+	return later, nil
+}
+
+// RejectDuplicates 出现重复模式串时返回 ErrDuplicatePattern
+func RejectDuplicates[T any](pattern string, old, _ T) (T, error) {
+	return old, fmt.Errorf("%w %q", ErrDuplicatePattern, pattern)
+}
+
+// Dictionary 带附加数据的AC自动机，匹配结果中附带模式串的附加数据
+type Dictionary[T any] struct {
+	tree     *ACTree
+	payloads []T // 附加数据，下标与 Match.Index 对应
+}
+
+// BuildDictionary 使用带附加数据的模式串集合构建AC自动机，merge为nil时使用 KeepFirst
+// 重复的模式串只保留第一次出现的下标，附加数据由merge依次合并到该下标上
+func BuildDictionary[T any](entries []Entry[T], merge MergeFunc[T], opts ...Option) (*Dictionary[T], error) {
+	if merge == nil {
+		merge = KeepFirst[T]
+	}
+	d := &Dictionary[T]{tree: NewAc(opts...), payloads: make([]T, len(entries))}
+	patterns := make([]string, len(entries))
+	first := make(map[string]int, len(entries))
+	for i, e := range entries {
+		patterns[i] = e.Pattern
+		d.payloads[i] = e.Payload
+		key := string(d.tree.opts.foldString(e.Pattern))
+		// 空模式串和变换后为空的模式串不会产生匹配，不参与合并
+		if len(key) == 0 {
+			continue
+		}
+		j, ok := first[key]
+		if !ok {
+			first[key] = i
+			continue
+		}
+		merged, err := merge(e.Pattern, d.payloads[j], e.Payload)
+		if err != nil {
+			return nil, err
+		}
+		d.payloads[j] = merged
+	}
+	if err := d.tree.Build(patterns); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Payload 返回下标为index的模式串的附加数据（重复的模式串合并后的结果保存在第一次出现的下标上）
+func (d *Dictionary[T]) Payload(index int) T {
+	return d.payloads[index]
+}
+
+// with 为匹配结果附加模式串的附加数据
+func (d *Dictionary[T]) with(m Match) PayloadMatch[T] {
+	return PayloadMatch[T]{Match: m, Payload: d.payloads[m.Index]}
+}
+
+// FindFirst 返回文本中最先出现的匹配
+func (d *Dictionary[T]) FindFirst(text string) (PayloadMatch[T], bool) {
+	m, ok := d.tree.FindFirst(text)
+	if !ok {
+		return PayloadMatch[T]{}, false
+	}
+	return d.with(m), true
+}
+
+// FindAll 按匹配语义返回文本中的匹配
+func (d *Dictionary[T]) FindAll(text string) []PayloadMatch[T] {
+	matches := d.tree.FindAll(text)
+	result := make([]PayloadMatch[T], len(matches))
+	for i, m := range matches {
+		result[i] = d.with(m)
+	}
+	return result
+}
+
+// Contains 判断文本中是否包含任一模式串
+func (d *Dictionary[T]) Contains(text string) bool {
+	return d.tree.Contains(text)
+}
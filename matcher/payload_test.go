@@ -0,0 +1,111 @@
+package matcher
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type category struct {
+	Name     string
+	Severity int
+}
+
+func TestDictionary(t *testing.T) {
+	entries := []Entry[category]{
+		{Pattern: "赌博", Payload: category{"gambling", 2}},
+		{Pattern: "发票", Payload: category{"fraud", 1}},
+		{Pattern: "代开发票", Payload: category{"fraud", 3}},
+	}
+	d, err := BuildDictionary(entries, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []PayloadMatch[category]{
+		{Match: Match{Index: 2, Pattern: "代开发票", Start: 0, End: 12, RuneStart: 0, RuneEnd: 4}, Payload: category{"fraud", 3}},
+		{Match: Match{Index: 1, Pattern: "发票", Start: 6, End: 12, RuneStart: 2, RuneEnd: 4}, Payload: category{"fraud", 1}},
+		{Match: Match{Index: 0, Pattern: "赌博", Start: 15, End: 21, RuneStart: 5, RuneEnd: 7}, Payload: category{"gambling", 2}},
+	}
+	text := "代开发票和赌博"
+	if got := d.FindAll(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll() = %+v, want %+v", got, want)
+	}
+	if got, ok := d.FindFirst(text); !ok || !reflect.DeepEqual(got, want[0]) {
+		t.Errorf("FindFirst() = %+v, %v, want %+v", got, ok, want[0])
+	}
+	if _, ok := d.FindFirst("正常文本"); ok || d.Contains("正常文本") {
+		t.Error("unexpected match in 正常文本")
+	}
+	if got := d.Payload(0); got.Name != "gambling" {
+		t.Errorf("Payload(0) = %+v", got)
+	}
+}
+
+func TestDictionaryDuplicates(t *testing.T) {
+	entries := []Entry[int]{
+		{Pattern: "Spam", Payload: 1},
+		{Pattern: "", Payload: 9},
+		{Pattern: "spam", Payload: 3},
+		{Pattern: "SPAM", Payload: 2},
+	}
+	opts := []Option{WithCaseFold(SimpleCaseFold)}
+	tests := []struct {
+		name  string
+		merge MergeFunc[int]
+		want  int
+	}{
+		{"默认保留第一个", nil, 1},
+		{"保留第一个", KeepFirst[int], 1},
+		{"保留最后一个", KeepLast[int], 2},
+		{"自定义合并", func(_ string, old, later int) (int, error) { return max(old, later), nil }, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := BuildDictionary(entries, tt.merge, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, ok := d.FindFirst("no spam here")
+			if !ok || m.Index != 0 || m.Payload != tt.want {
+				t.Errorf("FindFirst() = %+v, %v, want payload %d", m, ok, tt.want)
+			}
+		})
+	}
+
+	if _, err := BuildDictionary(entries, RejectDuplicates[int], opts...); !errors.Is(err, ErrDuplicatePattern) {
+		t.Errorf("BuildDictionary() error = %v, want ErrDuplicatePattern", err)
+	}
+	// 不折叠大小写时没有重复的模式串
+	if _, err := BuildDictionary(entries, RejectDuplicates[int]); err != nil {
+		t.Errorf("BuildDictionary() error = %v", err)
+	}
+}
+
+// 测试只由可忽略字符组成的模式串不参与合并
+func TestDictionaryIgnorable(t *testing.T) {
+	entries := []Entry[int]{
+		{Pattern: "*", Payload: 1},
+		{Pattern: "**", Payload: 2},
+		{Pattern: "a*b", Payload: 3},
+	}
+	d, err := BuildDictionary(entries, RejectDuplicates[int], WithIgnorable(IgnoreRunes("*"), -1))
+	if err != nil {
+		t.Fatalf("BuildDictionary() error = %v", err)
+	}
+	if got := d.FindAll("** ab *"); len(got) != 1 || got[0].Index != 2 || got[0].Payload != 3 {
+		t.Errorf("FindAll() = %+v", got)
+	}
+	if d.Payload(0) != 1 || d.Payload(1) != 2 {
+		t.Errorf("Payload() = %d %d, want 1 2", d.Payload(0), d.Payload(1))
+	}
+}
+
+func TestDictionaryPinyin(t *testing.T) {
+	d, err := BuildDictionary([]Entry[string]{{Pattern: "敏感词", Payload: "politics"}}, nil, WithPinyin(PinyinInitials, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m, ok := d.FindFirst("mgc"); !ok || m.Payload != "politics" || m.Form != PinyinInitials {
+		t.Errorf("FindFirst() = %+v, %v", m, ok)
+	}
+}
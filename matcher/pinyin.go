@@ -0,0 +1,247 @@
+package matcher
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PinyinForm 模式串的拼音形式，可以按位组合
+type PinyinForm uint8
+
+const (
+	// PinyinNone 原始模式串
+	PinyinNone PinyinForm = 0
+	// PinyinFull 全拼，例如 "敏感词" 的 "minganci"
+	PinyinFull PinyinForm = 1 << 0
+	// PinyinInitials 拼音首字母，例如 "敏感词" 的 "mgc"
+	PinyinInitials PinyinForm = 1 << 1
+)
+
+// maxPinyinForms 多音字组合时每个模式串的每种形式最多生成的拼音数
+const maxPinyinForms = 16
+
+// String 返回拼音形式的名称，多个形式以 "|" 连接
+func (f PinyinForm) String() string {
+	switch f {
+	case PinyinNone:
+		return "PinyinNone"
+	case PinyinFull:
+		return "PinyinFull"
+	case PinyinInitials:
+		return "PinyinInitials"
+	case PinyinFull | PinyinInitials:
+		return "PinyinFull|PinyinInitials"
+	}
+	return "PinyinForm(?)"
+}
+
+// PinyinTable 汉字拼音表，一个汉字可以有多个读音，第一个为最常用的读音
+type PinyinTable struct {
+	readings map[rune][]string
+}
+
+// NewPinyinTable 创建空的拼音表
+func NewPinyinTable() *PinyinTable {
+	return &PinyinTable{readings: make(map[rune][]string)}
+}
+
+// DefaultPinyinTable 返回内置的常用汉字拼音表，每次调用返回新的拼音表
+func DefaultPinyinTable() *PinyinTable {
+	t := NewPinyinTable()
+	for _, data := range [][]string{builtinPinyin, builtinPinyinExtra} {
+		for _, item := range data {
+			reading, chars, _ := strings.Cut(item, ":")
+			for _, c := range chars {
+				t.Add(c, reading)
+			}
+		}
+	}
+	return t
+}
+
+// Add 为汉字追加读音，读音会去掉声调并转换为小写，ü 转换为 v，已有的读音会被忽略
+// 读音规范化后不是纯字母时返回false
+func (t *PinyinTable) Add(r rune, readings ...string) bool {
+	for _, reading := range readings {
+		reading, ok := normalizePinyin(reading)
+		if !ok {
+			return false
+		}
+		if !slices.Contains(t.readings[r], reading) {
+			t.readings[r] = append(t.readings[r], reading)
+		}
+	}
+	return true
+}
+
+// Readings 返回汉字的所有读音，不在拼音表中时返回nil
+func (t *PinyinTable) Readings(r rune) []string {
+	return slices.Clone(t.readings[r])
+}
+
+// Len 返回拼音表中的汉字数量
+func (t *PinyinTable) Len() int {
+	return len(t.readings)
+}
+
+// pinyinTones 带声调的韵母到不带声调字母的映射
+var pinyinTones = strings.NewReplacer(
+	"ā", "a", "á", "a", "ǎ", "a", "à", "a",
+	"ē", "e", "é", "e", "ě", "e", "è", "e",
+	"ī", "i", "í", "i", "ǐ", "i", "ì", "i",
+	"ō", "o", "ó", "o", "ǒ", "o", "ò", "o",
+	"ū", "u", "ú", "u", "ǔ", "u", "ù", "u",
+	"ü", "v", "ǖ", "v", "ǘ", "v", "ǚ", "v", "ǜ", "v",
+)
+
+// normalizePinyin 规范化读音：去掉声调符号和数字声调，转换为小写
+func normalizePinyin(reading string) (string, bool) {
+	reading = pinyinTones.Replace(strings.ToLower(reading))
+	reading = strings.TrimRight(reading, "012345")
+	if reading == "" {
+		return "", false
+	}
+	for i := 0; i < len(reading); i++ {
+		if reading[i] < 'a' || reading[i] > 'z' {
+			return "", false
+		}
+	}
+	return reading, true
+}
+
+// ReadPinyinTable 从r中读取拼音表
+//
+// 每行为一个汉字及其读音，以空白分隔，例如 "行 xing hang"，读音可以带声调（"xíng" 或 "xing2"）。
+// 空行和以 # 开头的行会被跳过。
+func ReadPinyinTable(r io.Reader) (*PinyinTable, error) {
+	t := NewPinyinTable()
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		c, size := utf8.DecodeRuneInString(fields[0])
+		if size != len(fields[0]) || c == utf8.RuneError || len(fields) < 2 {
+			return nil, fmt.Errorf("matcher: pinyin table line %d: want a character followed by readings", line)
+		}
+		if !t.Add(c, fields[1:]...) {
+			return nil, fmt.Errorf("matcher: pinyin table line %d: invalid reading", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// LoadPinyinTable 从文件中读取拼音表，文件格式见 ReadPinyinTable
+func LoadPinyinTable(path string) (*PinyinTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadPinyinTable(f)
+}
+
+// WithPinyin 为每个包含汉字的模式串额外生成拼音形式并编译到同一个自动机中，table为nil时使用内置拼音表
+//
+// 拼音形式由小写字母组成，非汉字字符保持不变，多音字会生成多个拼音（每种形式最多 maxPinyinForms 个）。
+// 匹配到拼音形式时 Match 的 Index 和 Pattern 仍然是原始模式串，Form 给出匹配到的形式。
+// 模式串含有拼音表中没有的汉字时不生成拼音形式。文本中的大写拼音需要配合 WithCaseFold 使用。
+// ACTree 和 AC 支持该配置。
+func WithPinyin(forms PinyinForm, table *PinyinTable) Option {
+	if table == nil && forms != PinyinNone {
+		table = DefaultPinyinTable()
+	}
+	return func(o *options) {
+		o.pinyin = forms
+		o.pinyinTable = table
+	}
+}
+
+// pinyinEntry 模式串的一个拼音形式
+type pinyinEntry struct {
+	index  int        // 原始模式串下标
+	text   string     // 拼音形式的文本
+	length int        // 文本长度（按rune计算，启用大小写折叠或规范化时为变换后的长度）
+	form   PinyinForm // 拼音形式
+}
+
+// pinyinForms 按配置生成模式串的所有拼音形式
+func (o *options) pinyinForms(pattern string, index int) []pinyinEntry {
+	var entries []pinyinEntry
+	for _, form := range []PinyinForm{PinyinFull, PinyinInitials} {
+		if o.pinyin&form == 0 {
+			continue
+		}
+		for _, text := range o.pinyinTable.spell(pattern, form == PinyinInitials) {
+			entries = append(entries, pinyinEntry{index: index, text: text, length: len(o.foldString(text)), form: form})
+		}
+	}
+	return entries
+}
+
+// spell 返回模式串的所有拼音组合，initials为true时只取每个读音的首字母
+// 模式串不含汉字或含有拼音表中没有的汉字时返回nil
+func (t *PinyinTable) spell(pattern string, initials bool) []string {
+	results := []string{""}
+	hasHan := false
+	for _, r := range pattern {
+		choices := []string{string(r)}
+		if unicode.Is(unicode.Han, r) {
+			readings := t.readings[r]
+			if len(readings) == 0 {
+				return nil
+			}
+			hasHan = true
+			choices = choices[:0]
+			for _, reading := range readings {
+				if initials {
+					reading = reading[:1]
+				}
+				if !slices.Contains(choices, reading) {
+					choices = append(choices, reading)
+				}
+			}
+		}
+		next := make([]string, 0, min(len(results)*len(choices), maxPinyinForms))
+		for _, prefix := range results {
+			for _, choice := range choices {
+				if len(next) < maxPinyinForms {
+					next = append(next, prefix+choice)
+				}
+			}
+		}
+		results = next
+	}
+	if !hasHan {
+		return nil
+	}
+	return results
+}
+
+// expandPinyin 按配置生成模式串集合中所有模式串的拼音形式，未启用拼音匹配时返回nil
+func (o *options) expandPinyin(patterns []string) []pinyinEntry {
+	if o.pinyin == PinyinNone || o.pinyinTable == nil {
+		return nil
+	}
+	var entries []pinyinEntry
+	for i, pattern := range patterns {
+		entries = append(entries, o.pinyinForms(pattern, i)...)
+	}
+	return entries
+}
+
+// formID 返回第k个拼音形式在输出集合中的编号，拼音形式使用负数编号以区别于模式串下标
+// 该变换是自身的逆变换，formID(id) 即编号对应的拼音形式下标
+func formID(k int) int {
+	return -k - 1
+}
@@ -0,0 +1,462 @@
+package matcher
+
+// builtinPinyin 内置的常用汉字拼音表，每项为 "拼音:汉字"，拼音不带声调，ü 写作 v，
+// 每个汉字只出现一次，对应的拼音为该字最常用的读音
+var builtinPinyin = []string{
+	"a:阿啊",
+	"ai:爱哀挨唉矮艾碍癌埃蔼隘",
+	"an:安按暗岸案俺鞍氨庵",
+	"ang:昂肮",
+	"ao:奥澳傲熬凹袄敖",
+	"ba:八把爸吧巴拔霸罢叭扒疤捌芭靶",
+	"bai:白百败摆拜柏佰",
+	"ban:办半板班般版搬伴扮拌斑颁瓣绊",
+	"bang:帮棒榜膀绑傍磅谤邦",
+	"bao:包保报宝抱暴爆饱豹堡薄胞雹苞褒",
+	"bei:北被背杯备悲贝倍辈碑卑",
+	"ben:本奔笨苯",
+	"beng:崩绷蹦泵",
+	"bi:比笔必毕闭币避鼻彼逼壁臂碧弊蔽鄙痹毙",
+	"bian:边变便遍编辩鞭扁辨贬",
+	"biao:表标彪膘",
+	"bie:别憋瘪",
+	"bin:宾滨彬斌濒",
+	"bing:兵病并冰饼丙柄秉",
+	"bo:波播博伯玻拨驳脖勃泊搏膊舶剥",
+	"bu:不部步布补捕簿哺",
+	"ca:擦",
+	"cai:才菜采财材彩猜裁踩睬",
+	"can:参残餐惨灿蚕",
+	"cang:藏苍仓舱沧",
+	"cao:草操曹槽糙",
+	"ce:测策侧册厕",
+	"ceng:层曾蹭",
+	"cha:查茶差插察叉岔诧",
+	"chai:拆柴豺",
+	"chan:产缠铲颤蝉馋",
+	"chang:长常场唱厂尝肠畅昌偿敞",
+	"chao:超朝抄吵潮巢炒钞",
+	"che:车彻撤扯",
+	"chen:陈沉晨尘臣衬趁辰",
+	"cheng:成城程称承乘诚撑橙惩秤呈",
+	"chi:吃持迟尺池赤齿翅耻驰斥痴",
+	"chong:冲充虫崇宠",
+	"chou:抽丑愁臭仇筹稠绸酬",
+	"chu:出处初除楚触础储厨锄雏畜",
+	"chuan:传船穿川串喘",
+	"chuang:窗床创闯疮",
+	"chui:吹垂锤炊",
+	"chun:春纯唇蠢醇",
+	"ci:次此词刺辞磁慈瓷雌",
+	"cong:从聪葱匆丛",
+	"cou:凑",
+	"cu:粗促醋簇",
+	"cuan:窜篡",
+	"cui:催脆翠摧崔",
+	"cun:村存寸",
+	"cuo:错措挫搓",
+	"da:大打达答搭",
+	"dai:带代待袋戴呆贷逮怠",
+	"dan:但单担蛋淡胆丹旦弹诞",
+	"dang:当党档挡荡",
+	"dao:到道导倒刀岛盗稻蹈悼",
+	"de:的得德",
+	"deng:等灯登邓凳瞪",
+	"di:地第低底敌弟帝递滴抵堤笛",
+	"dian:点电店典殿垫淀颠",
+	"diao:调掉吊钓雕",
+	"die:跌爹叠蝶",
+	"ding:定顶订丁盯钉鼎",
+	"diu:丢",
+	"dong:动东懂冬洞冻栋",
+	"dou:都斗豆逗抖陡兜",
+	"du:度读独毒肚渡堵赌杜督镀",
+	"duan:段短断端锻",
+	"dui:对队堆兑",
+	"dun:顿吨蹲盾墩",
+	"duo:多朵夺躲堕舵",
+	"e:饿额恶俄鹅蛾扼",
+	"en:恩",
+	"er:而二儿耳尔饵",
+	"fa:发法罚乏伐阀",
+	"fan:反饭翻犯范凡烦泛番繁帆返贩",
+	"fang:方放房防访仿纺芳妨",
+	"fei:非飞费肥废肺匪沸诽吠",
+	"fen:分份粉纷奋愤坟芬",
+	"feng:风封丰峰疯锋蜂逢缝奉凤讽",
+	"fo:佛",
+	"fou:否",
+	"fu:服父复府付副富夫福负妇附扶浮符幅伏腐辅赋抚覆俘肤",
+	"ga:嘎",
+	"gai:该改概盖丐钙",
+	"gan:感干敢赶甘肝杆秆竿",
+	"gang:刚钢港岗纲缸",
+	"gao:高告搞稿糕膏",
+	"ge:个各歌哥格隔革割搁鸽阁",
+	"gei:给",
+	"gen:根跟",
+	"geng:更耕",
+	"gong:工公共功攻供宫恭巩贡拱",
+	"gou:够狗构购沟钩勾",
+	"gu:古故顾鼓骨固谷估孤姑股雇",
+	"gua:挂瓜刮寡",
+	"guai:怪乖拐",
+	"guan:关观管官惯馆冠罐灌贯",
+	"guang:光广逛",
+	"gui:规贵鬼归跪柜轨桂",
+	"gun:滚棍",
+	"guo:国过果锅裹郭",
+	"ha:哈",
+	"hai:还海孩害骇",
+	"han:汉喊含寒汗韩旱憾",
+	"hang:航杭",
+	"hao:好号毫豪耗浩",
+	"he:和合河喝何核盒荷贺赫",
+	"hei:黑嘿",
+	"hen:很恨狠痕",
+	"heng:横衡恒哼",
+	"hong:红洪轰宏虹哄",
+	"hou:后候厚喉猴吼",
+	"hu:户呼湖护胡互忽虎壶糊蝴狐乎",
+	"hua:话化花华画划滑",
+	"huai:坏怀淮",
+	"huan:换欢环缓患唤幻",
+	"huang:黄皇荒慌煌晃谎",
+	"hui:会回灰挥汇辉毁悔惠绘恢慧",
+	"hun:婚混魂昏浑",
+	"huo:或活火货获伙祸惑",
+	"ji:机几己级记及急基计技集击极积际纪继济既鸡迹寂激季剂忌肌饥吉籍疾辑挤脊",
+	"jia:家加价假架甲嘉夹佳驾嫁",
+	"jian:见间件建简检坚健减剑肩键尖兼监渐践鉴舰箭荐溅艰拣",
+	"jiang:将讲江奖降姜僵酱浆疆",
+	"jiao:叫教交较角脚觉焦骄胶郊娇浇搅狡",
+	"jie:接结界节解姐街阶介借戒届洁截杰揭劫皆",
+	"jin:进今金近仅紧尽禁斤津筋锦劲浸晋",
+	"jing:经精京静竟境景警井惊镜敬净睛径颈晶",
+	"jiong:窘",
+	"jiu:就九久酒旧救究纠舅揪",
+	"ju:局举句具据剧居聚巨拒俱距菊橘鞠",
+	"juan:卷捐倦娟",
+	"jue:决绝掘爵",
+	"jun:军均君菌俊峻",
+	"ka:卡咖",
+	"kai:开凯慨",
+	"kan:看砍刊堪",
+	"kang:康抗扛炕",
+	"kao:考靠烤",
+	"ke:可科克客刻课颗渴棵壳咳",
+	"ken:肯啃恳垦",
+	"keng:坑",
+	"kong:空控孔恐",
+	"kou:口扣寇",
+	"ku:苦哭库裤酷枯窟",
+	"kua:夸跨垮",
+	"kuai:快块筷",
+	"kuan:宽款",
+	"kuang:况狂矿框旷",
+	"kui:亏愧溃葵",
+	"kun:困昆捆",
+	"kuo:扩括阔",
+	"la:拉啦辣蜡垃",
+	"lai:来赖",
+	"lan:蓝兰烂拦篮懒栏览滥",
+	"lang:浪狼郎朗廊",
+	"lao:老劳牢捞",
+	"le:了乐勒",
+	"lei:类累泪雷垒擂",
+	"leng:冷愣",
+	"li:里理力利立离例历李礼丽励粒厉梨黎犁璃隶哩",
+	"lia:俩",
+	"lian:连脸练联恋怜莲廉帘炼镰",
+	"liang:两量亮良凉粮梁辆谅晾",
+	"liao:料疗聊辽僚",
+	"lie:列烈裂猎劣",
+	"lin:林临邻淋琳磷",
+	"ling:领另令零灵龄铃岭玲凌陵",
+	"liu:六流留刘柳溜",
+	"long:龙笼隆聋拢",
+	"lou:楼漏搂",
+	"lu:路陆录露炉鲁卢鹿碌",
+	"lv:绿旅律虑率驴铝屡",
+	"luan:乱卵",
+	"lve:略掠",
+	"lun:论轮伦",
+	"luo:落罗络洛骆螺锣逻",
+	"ma:马妈吗麻骂码玛嘛",
+	"mai:买卖麦埋迈脉",
+	"man:满慢漫蛮馒瞒",
+	"mang:忙盲茫芒",
+	"mao:毛猫冒帽貌茂矛贸",
+	"me:么",
+	"mei:没每美妹煤梅媒眉霉",
+	"men:们门闷",
+	"meng:梦猛蒙盟孟",
+	"mi:米密秘迷蜜谜眯觅",
+	"mian:面免棉眠绵勉",
+	"miao:秒苗描庙妙",
+	"mie:灭蔑",
+	"min:民敏抿皿",
+	"ming:明名命鸣铭",
+	"mo:末模磨摸默莫魔墨膜漠陌",
+	"mou:某谋",
+	"mu:目母木幕慕牧墓亩暮",
+	"na:那拿哪纳娜",
+	"nai:奶乃耐",
+	"nan:男难南",
+	"nang:囊",
+	"nao:脑闹恼",
+	"ne:呢",
+	"nei:内",
+	"nen:嫩",
+	"neng:能",
+	"ni:你泥尼逆拟腻",
+	"nian:年念粘黏",
+	"niang:娘",
+	"niao:鸟尿",
+	"nie:捏",
+	"nin:您",
+	"ning:宁凝拧",
+	"niu:牛扭纽",
+	"nong:农弄浓",
+	"nu:努怒奴",
+	"nv:女",
+	"nuan:暖",
+	"nve:虐",
+	"nuo:诺挪",
+	"ou:欧偶",
+	"pa:怕爬",
+	"pai:排派拍牌",
+	"pan:盘判盼攀",
+	"pang:旁胖",
+	"pao:跑炮抛泡袍",
+	"pei:配陪培赔佩",
+	"pen:喷盆",
+	"peng:朋碰棚蓬膨捧",
+	"pi:批皮披疲脾匹屁譬僻",
+	"pian:片篇骗偏",
+	"piao:票漂飘",
+	"pie:撇",
+	"pin:品拼贫频",
+	"ping:平评瓶凭苹屏",
+	"po:破坡泼颇婆迫",
+	"pou:剖",
+	"pu:普铺扑朴葡仆谱浦",
+	"qi:起其气期七器奇齐汽企妻旗骑启弃棋欺漆歧",
+	"qia:恰",
+	"qian:前钱千签欠浅牵铅谦迁潜遣",
+	"qiang:强枪墙抢腔",
+	"qiao:桥巧瞧敲悄侨乔",
+	"qie:切且窃",
+	"qin:亲琴勤侵秦禽",
+	"qing:情请清青轻晴庆顷",
+	"qiong:穷",
+	"qiu:求球秋丘",
+	"qu:去取区曲趣屈驱渠",
+	"quan:全权劝泉拳圈券",
+	"que:却确缺雀",
+	"qun:群裙",
+	"ran:然燃染",
+	"rang:让嚷",
+	"rao:绕扰饶",
+	"re:热惹",
+	"ren:人认任忍仁刃",
+	"reng:仍扔",
+	"ri:日",
+	"rong:容荣融绒溶",
+	"rou:肉柔揉",
+	"ru:如入乳辱",
+	"ruan:软",
+	"rui:锐瑞",
+	"run:润闰",
+	"ruo:若弱",
+	"sa:撒洒萨",
+	"sai:赛塞腮",
+	"san:三散伞",
+	"sang:桑丧嗓",
+	"sao:扫嫂骚",
+	"se:色涩",
+	"sen:森",
+	"sha:杀沙傻纱啥",
+	"shai:晒筛",
+	"shan:山善闪扇衫删陕",
+	"shang:上商伤尚赏",
+	"shao:少烧绍稍勺哨",
+	"she:社设射舍蛇摄涉舌",
+	"shei:谁",
+	"shen:身深神什甚申伸审慎肾",
+	"sheng:生声胜省升圣剩牲绳",
+	"shi:是时事十市使式实师始世视史识示石食士室施诗失试适势湿释誓驶饰",
+	"shou:手受收首守授寿兽售瘦",
+	"shu:书数树术输属叔熟鼠束述暑舒殊蔬梳薯",
+	"shua:刷耍",
+	"shuai:帅摔衰",
+	"shuan:拴",
+	"shuang:双霜爽",
+	"shui:水睡税",
+	"shun:顺瞬",
+	"shuo:说硕",
+	"si:四死思司私丝似斯寺撕肆",
+	"song:送松宋颂诵",
+	"sou:搜艘",
+	"su:速素诉苏俗宿塑肃",
+	"suan:算酸蒜",
+	"sui:随岁虽碎遂穗",
+	"sun:孙损笋",
+	"suo:所锁缩索",
+	"ta:他她它塔踏",
+	"tai:太台态泰抬胎",
+	"tan:谈探叹摊贪坦滩炭",
+	"tang:堂汤唐糖躺趟塘",
+	"tao:讨逃套桃淘陶涛",
+	"te:特",
+	"teng:疼腾藤",
+	"ti:体题提替梯踢蹄",
+	"tian:天田甜填添",
+	"tiao:条跳挑",
+	"tie:铁贴",
+	"ting:听停庭挺厅亭",
+	"tong:同通统痛童桶铜筒",
+	"tou:头投透偷",
+	"tu:图土突徒途涂吐兔",
+	"tuan:团",
+	"tui:推退腿",
+	"tun:吞屯",
+	"tuo:脱托拖妥驼",
+	"wa:哇挖娃瓦袜",
+	"wai:外歪",
+	"wan:万完晚玩湾碗弯丸顽挽",
+	"wang:王望往网忘亡旺",
+	"wei:为位未委维味围卫微伟危威胃谓喂尾违唯",
+	"wen:问文闻温稳吻纹",
+	"weng:翁",
+	"wo:我握卧窝",
+	"wu:无五物务午武误屋舞污雾吴悟乌",
+	"xi:西系息习喜希细析席洗吸戏惜溪稀锡熄",
+	"xia:下夏吓虾瞎峡霞",
+	"xian:先现线县显险限鲜献仙闲陷宪贤嫌",
+	"xiang:想向相像象香乡响项箱详享巷",
+	"xiao:小校笑消效晓销孝萧",
+	"xie:些写谢协鞋斜携泄械蟹",
+	"xin:新心信辛欣薪",
+	"xing:行性形型星兴姓醒幸",
+	"xiong:雄兄胸凶熊",
+	"xiu:修休秀袖绣",
+	"xu:需许须续序虚蓄徐叙",
+	"xuan:选宣悬旋玄",
+	"xue:学雪血穴",
+	"xun:训寻讯迅询循",
+	"ya:呀压牙亚雅鸭芽",
+	"yan:眼研严言验烟沿颜延盐演岩宴艳炎",
+	"yang:样阳洋养羊仰杨扬",
+	"yao:要药摇腰咬遥邀",
+	"ye:也业夜爷叶页野液",
+	"yi:一以已意义议医衣移依易亿艺疑仪益宜忆异役",
+	"yin:因音引印银饮阴隐",
+	"ying:应影营英迎硬赢映鹰",
+	"yo:哟",
+	"yong:用永勇拥涌",
+	"you:有又由友右油游优邮尤幽犹",
+	"yu:于与语育雨遇鱼余预域欲玉宇狱羽愈浴誉御",
+	"yuan:员原元远院愿园源圆援怨",
+	"yue:月越约跃阅岳",
+	"yun:云运允孕晕",
+	"za:杂砸",
+	"zai:在再载灾",
+	"zan:赞咱暂",
+	"zang:脏葬",
+	"zao:早造遭澡躁燥",
+	"ze:则责泽择",
+	"zei:贼",
+	"zen:怎",
+	"zeng:增赠",
+	"zha:炸扎渣闸眨榨",
+	"zhai:摘债宅窄",
+	"zhan:战站展占沾斩",
+	"zhang:张章涨掌丈账仗障",
+	"zhao:找照招召赵罩兆",
+	"zhe:这着者折哲",
+	"zhen:真针阵镇珍震振诊枕",
+	"zheng:正政证争整征郑症睁蒸挣",
+	"zhi:之只知制指直治至纸职止值志支质智植织置址执致",
+	"zhong:中种重众钟终忠肿",
+	"zhou:周州洲轴舟皱昼",
+	"zhu:主住注助著逐竹珠煮诸猪祝筑驻柱铸",
+	"zhua:抓",
+	"zhuan:专转砖赚",
+	"zhuang:装状壮庄撞",
+	"zhui:追坠",
+	"zhun:准",
+	"zhuo:桌捉卓浊",
+	"zi:自子字资紫姿仔",
+	"zong:总宗综纵踪棕",
+	"zou:走奏揍",
+	"zu:组足族祖租阻",
+	"zuan:钻",
+	"zui:最嘴醉罪",
+	"zun:尊遵",
+	"zuo:作做坐座左昨",
+}
+
+// builtinPinyinExtra 内置拼音表中多音字的其他读音，格式与 builtinPinyin 相同
+var builtinPinyinExtra = []string{
+	"hang:行",
+	"zhang:长",
+	"bo:薄",
+	"zhuan:传",
+	"jue:觉角",
+	"jing:劲",
+	"liao:了",
+	"yue:乐",
+	"piao:朴",
+	"du:都",
+	"tiao:调",
+	"chong:重",
+	"xie:血解",
+	"zhao:着朝",
+	"zhuo:着",
+	"tan:弹",
+	"zang:藏",
+	"shui:说谁",
+	"lou:露",
+	"mo:没",
+	"chai:差",
+	"ci:差",
+	"shan:单",
+	"dei:得",
+	"di:的",
+	"huo:和",
+	"huan:还",
+	"kuai:会",
+	"xiang:降",
+	"qiang:将",
+	"ji:系给",
+	"xing:省",
+	"shuo:数",
+	"zeng:曾",
+	"shen:参",
+	"chen:称",
+	"dai:大",
+	"shi:似什",
+	"pian:便",
+	"qia:卡",
+	"qiao:壳",
+	"se:塞",
+	"jiang:强",
+	"ou:区",
+	"qiu:仇",
+	"ju:车",
+	"jiao:校",
+	"wu:恶",
+	"shai:色",
+	"la:落",
+	"za:扎",
+	"she:折",
+	"he:吓",
+	"duo:度",
+	"fu:佛",
+	"lu:绿",
+	"shou:熟",
+	"xiu:宿",
+	"xu:畜",
+	"mu:模",
+}
@@ -0,0 +1,250 @@
+package matcher
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// 构建支持拼音形式的 Matcher 实现
+func buildPinyinMatchers(t *testing.T, patterns []string, opts ...Option) map[string]Matcher {
+	matchers := map[string]Matcher{
+		"ACTree": NewAc(opts...),
+		"AC":     NewAC(opts...),
+	}
+	for name, m := range matchers {
+		if err := m.Build(patterns); err != nil {
+			t.Fatalf("%s Build() error = %v", name, err)
+		}
+	}
+	return matchers
+}
+
+func TestPinyinMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		text     string
+		patterns []string
+		want     []Match
+	}{
+		{
+			name:     "全拼和首字母",
+			opts:     []Option{WithPinyin(PinyinFull|PinyinInitials, nil), WithCaseFold(SimpleCaseFold)},
+			text:     "这是minganci和MGC，敏感词",
+			patterns: []string{"敏感词", "abc"},
+			want: []Match{
+				{Index: 0, Pattern: "敏感词", Start: 6, End: 14, RuneStart: 2, RuneEnd: 10, Form: PinyinFull},
+				{Index: 0, Pattern: "敏感词", Start: 17, End: 20, RuneStart: 11, RuneEnd: 14, Form: PinyinInitials},
+				{Index: 0, Pattern: "敏感词", Start: 23, End: 32, RuneStart: 15, RuneEnd: 18},
+			},
+		},
+		{
+			name:     "只启用首字母",
+			opts:     []Option{WithPinyin(PinyinInitials, nil)},
+			text:     "minganci mgc",
+			patterns: []string{"敏感词"},
+			want: []Match{
+				{Index: 0, Pattern: "敏感词", Start: 9, End: 12, RuneStart: 9, RuneEnd: 12, Form: PinyinInitials},
+			},
+		},
+		{
+			name:     "可忽略字符",
+			opts:     []Option{WithPinyin(PinyinFull, nil), WithCaseFold(SimpleCaseFold), WithIgnorable(IsNoise, -1)},
+			text:     "Min-Gan-Ci",
+			patterns: []string{"敏感词"},
+			want: []Match{
+				{Index: 0, Pattern: "敏感词", Start: 0, End: 10, RuneStart: 0, RuneEnd: 10, Form: PinyinFull},
+			},
+		},
+		{
+			name:     "多音字",
+			opts:     []Option{WithPinyin(PinyinFull|PinyinInitials, nil)},
+			text:     "yinhang yinxing yx",
+			patterns: []string{"银行"},
+			want: []Match{
+				{Index: 0, Pattern: "银行", Start: 0, End: 7, RuneStart: 0, RuneEnd: 7, Form: PinyinFull},
+				{Index: 0, Pattern: "银行", Start: 8, End: 15, RuneStart: 8, RuneEnd: 15, Form: PinyinFull},
+				{Index: 0, Pattern: "银行", Start: 16, End: 18, RuneStart: 16, RuneEnd: 18, Form: PinyinInitials},
+			},
+		},
+		{
+			name:     "非汉字保持不变",
+			opts:     []Option{WithPinyin(PinyinFull, nil)},
+			text:     "qq群 qqqun",
+			patterns: []string{"qq群", "qq"},
+			want: []Match{
+				{Index: 1, Pattern: "qq", Start: 0, End: 2, RuneStart: 0, RuneEnd: 2},
+				{Index: 0, Pattern: "qq群", Start: 0, End: 5, RuneStart: 0, RuneEnd: 3},
+				{Index: 1, Pattern: "qq", Start: 6, End: 8, RuneStart: 4, RuneEnd: 6},
+				{Index: 0, Pattern: "qq群", Start: 6, End: 11, RuneStart: 4, RuneEnd: 9, Form: PinyinFull},
+				{Index: 1, Pattern: "qq", Start: 7, End: 9, RuneStart: 5, RuneEnd: 7},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, m := range buildPinyinMatchers(t, tt.patterns, tt.opts...) {
+				if got := m.FindAll(tt.text); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("%s FindAll() = %+v, want %+v", name, got, tt.want)
+				}
+				if got, ok := m.FindFirst(tt.text); !ok || got != tt.want[0] {
+					t.Errorf("%s FindFirst() = %+v, %v, want %+v", name, got, ok, tt.want[0])
+				}
+			}
+		})
+	}
+}
+
+func TestPinyinInsertAndStream(t *testing.T) {
+	ac := NewAC(WithPinyin(PinyinFull|PinyinInitials, nil))
+	for _, pattern := range []string{"你好", "敏感词"} {
+		if err := ac.Insert(pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ac.BuildFail()
+	text := "nihao mgc"
+	want := []Match{
+		{Index: 0, Pattern: "你好", Start: 0, End: 5, RuneStart: 0, RuneEnd: 5, Form: PinyinFull},
+		{Index: 1, Pattern: "敏感词", Start: 6, End: 9, RuneStart: 6, RuneEnd: 9, Form: PinyinInitials},
+	}
+	if got := ac.FindAll(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll() = %+v, want %+v", got, want)
+	}
+	if got := ac.Search(text); !reflect.DeepEqual(got, map[string][]int{"你好": {0}, "敏感词": {6}}) {
+		t.Errorf("Search() = %v", got)
+	}
+
+	var got []Match
+	s := ac.NewStream(func(m Match) bool {
+		got = append(got, m)
+		return true
+	})
+	s.Write([]byte(text))
+	s.Flush()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Stream = %+v, want %+v", got, want)
+	}
+}
+
+func TestPinyinTable(t *testing.T) {
+	table := DefaultPinyinTable()
+	if got := table.Readings('行'); !slices.Equal(got, []string{"xing", "hang"}) {
+		t.Errorf("Readings(行) = %v", got)
+	}
+	if got := table.Readings('绿'); len(got) == 0 || got[0] != "lv" {
+		t.Errorf("Readings(绿) = %v", got)
+	}
+	if table.Readings('a') != nil {
+		t.Error("Readings(a) should be nil")
+	}
+
+	// 内置拼音表中每个汉字只有一个主要读音，读音都是合法的拼音
+	seen := make(map[rune]string)
+	for _, item := range builtinPinyin {
+		reading, chars, _ := strings.Cut(item, ":")
+		if _, ok := normalizePinyin(reading); !ok {
+			t.Errorf("invalid reading %q", reading)
+		}
+		for _, c := range chars {
+			if other, ok := seen[c]; ok {
+				t.Errorf("%c has readings %s and %s", c, other, reading)
+			}
+			seen[c] = reading
+		}
+	}
+	if table.Len() != len(seen) {
+		t.Errorf("Len() = %d, want %d", table.Len(), len(seen))
+	}
+
+	// 未知汉字不生成拼音形式
+	if got := table.spell("敏感𠀀", false); got != nil {
+		t.Errorf("spell() = %v, want nil", got)
+	}
+}
+
+func TestReadPinyinTable(t *testing.T) {
+	table, err := ReadPinyinTable(strings.NewReader("# 注释\n\n行 xíng HANG2\n绿 lǜ\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := table.Readings('行'); !slices.Equal(got, []string{"xing", "hang"}) {
+		t.Errorf("Readings(行) = %v", got)
+	}
+	if got := table.Readings('绿'); !slices.Equal(got, []string{"lv"}) {
+		t.Errorf("Readings(绿) = %v", got)
+	}
+	for _, input := range []string{"行\n", "行行 xing\n", "行 x-ing\n"} {
+		if _, err := ReadPinyinTable(strings.NewReader(input)); err == nil {
+			t.Errorf("ReadPinyinTable(%q) should fail", input)
+		}
+	}
+}
+
+func TestLoadPinyinTable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pinyin.txt")
+	if err := os.WriteFile(path, []byte("囧 jiong\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	table, err := LoadPinyinTable(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ac, err := BuildAC([]string{"好囧"}, WithPinyin(PinyinInitials, table))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 自定义拼音表中没有 "好"，不生成拼音形式
+	if ac.Contains("hj") {
+		t.Error("Contains(hj) = true")
+	}
+	if _, err := LoadPinyinTable(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("LoadPinyinTable() should fail for missing file")
+	}
+}
+
+func TestPinyinFormString(t *testing.T) {
+	for form, want := range map[PinyinForm]string{
+		PinyinNone:                  "PinyinNone",
+		PinyinFull:                  "PinyinFull",
+		PinyinInitials:              "PinyinInitials",
+		PinyinFull | PinyinInitials: "PinyinFull|PinyinInitials",
+		PinyinForm(8):               "PinyinForm(?)",
+	} {
+		if got := form.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
+
+// 测试不支持拼音形式的匹配器构建时返回错误
+func TestPinyinUnsupported(t *testing.T) {
+	opt := WithPinyin(PinyinFull, nil)
+	for name, m := range map[string]Matcher{
+		"Trie":       NewTrie(opt),
+		"BruteForce": NewBruteForce(opt),
+		"KMP":        NewKMP(opt),
+	} {
+		if err := m.Build([]string{"敏感词"}); !errors.Is(err, ErrUnsupportedOption) {
+			t.Errorf("%s Build() error = %v, want ErrUnsupportedOption", name, err)
+		}
+		if m.Contains("敏感词 minganci") {
+			t.Errorf("%s Contains() = true after failed Build", name)
+		}
+	}
+
+	var buf bytes.Buffer
+	if _, err := BuildTrie([]string{"敏感词"}).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadTrie(&buf, opt); !errors.Is(err, ErrUnsupportedOption) {
+		t.Errorf("ReadTrie() error = %v, want ErrUnsupportedOption", err)
+	}
+}
@@ -0,0 +1,189 @@
+package matcher
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+)
+
+// ReadWords 从r中读取词表
+//
+// 每行一个词，去掉首尾空白后为空的行和以 # 开头的行会被跳过。
+func ReadWords(r io.Reader) ([]string, error) {
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		if !utf8.ValidString(word) {
+			return nil, fmt.Errorf("matcher: word list line %d: invalid UTF-8", line)
+		}
+		words = append(words, word)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return words, nil
+}
+
+// LoadWords 从文件中读取词表，文件格式见 ReadWords
+func LoadWords(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadWords(f)
+}
+
+// fileStamp 用于判断文件是否被修改的文件状态
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Reloader 从词表文件加载并支持热更新的AC自动机，可以被多个goroutine并发使用
+//
+// 重新加载时在调用方的goroutine（例如 Watch 所在的后台goroutine）中构建新的自动机，
+// 构建完成后原子替换，替换前已经开始的查询继续使用旧的自动机完成。
+// 读取或构建失败时保留当前的词典，错误通过返回值、Watch 的回调和 LastError 报告。
+// 为避免读到写了一半的文件，更新词表时应先写入临时文件再重命名。
+type Reloader struct {
+	path    string
+	opts    []Option
+	current atomic.Pointer[ACTree]
+	lastErr atomic.Pointer[error]
+	mu      sync.Mutex // 保证同一时间只有一次加载
+	stamp   fileStamp  // 最近一次尝试加载时的文件状态
+}
+
+// NewReloader 从词表文件创建可热更新的AC自动机，opts 为构建自动机的配置
+// 第一次加载失败时返回错误
+func NewReloader(path string, opts ...Option) (*Reloader, error) {
+	r := &Reloader{path: path, opts: opts}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload 立即重新读取词表文件并替换当前的自动机，失败时保留当前的自动机并返回错误
+// 词表为空也视为错误，避免误删全部词典
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return r.fail(err)
+	}
+	return r.load(fileStamp{modTime: info.ModTime(), size: info.Size()})
+}
+
+// load 读取词表并替换当前的自动机，调用时需要持有 mu
+func (r *Reloader) load(stamp fileStamp) error {
+	r.stamp = stamp
+	words, err := LoadWords(r.path)
+	if err != nil {
+		return r.fail(err)
+	}
+	if len(words) == 0 {
+		return r.fail(fmt.Errorf("matcher: word list %s is empty", r.path))
+	}
+	tree := NewAc(r.opts...)
+	if err := tree.Build(words); err != nil {
+		return r.fail(err)
+	}
+	r.current.Store(tree)
+	r.lastErr.Store(nil)
+	return nil
+}
+
+// fail 记录加载错误
+func (r *Reloader) fail(err error) error {
+	r.lastErr.Store(&err)
+	return err
+}
+
+// reloadIfChanged 文件状态与上一次尝试加载时不同时重新加载，返回是否进行了加载
+func (r *Reloader) reloadIfChanged() (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	info, err := os.Stat(r.path)
+	if err != nil {
+		// 文件不存在等错误只报告一次，文件恢复后重新加载
+		if r.stamp == (fileStamp{}) {
+			return false, nil
+		}
+		r.stamp = fileStamp{}
+		return true, r.fail(err)
+	}
+	stamp := fileStamp{modTime: info.ModTime(), size: info.Size()}
+	if stamp == r.stamp {
+		return false, nil
+	}
+	return true, r.load(stamp)
+}
+
+// Watch 每隔interval检查一次词表文件的修改时间和大小，发生变化时重新加载，直到ctx结束
+// 每次加载后调用report（可以为nil），成功时参数为nil。同一个文件状态加载失败后不会重试，直到文件再次变化
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration, report func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if loaded, err := r.reloadIfChanged(); loaded && report != nil {
+				report(err)
+			}
+		}
+	}
+}
+
+// LastError 返回最近一次加载的错误，最近一次加载成功时返回nil
+func (r *Reloader) LastError() error {
+	if err := r.lastErr.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+// Build 使用模式串集合构建新的自动机并原子替换当前的自动机，不影响之后对词表文件的监视
+func (r *Reloader) Build(patterns []string) error {
+	tree := NewAc(r.opts...)
+	if err := tree.Build(patterns); err != nil {
+		return err
+	}
+	r.current.Store(tree)
+	return nil
+}
+
+// Scan 使用当前的自动机扫描文本，返回匹配到的词
+func (r *Reloader) Scan(text string) []string {
+	return r.current.Load().Scan(text)
+}
+
+// FindFirst 返回文本中最先出现的匹配
+func (r *Reloader) FindFirst(text string) (Match, bool) {
+	return r.current.Load().FindFirst(text)
+}
+
+// FindAll 按匹配语义返回文本中的匹配
+func (r *Reloader) FindAll(text string) []Match {
+	return r.current.Load().FindAll(text)
+}
+
+// Contains 判断文本中是否包含任一模式串
+func (r *Reloader) Contains(text string) bool {
+	return r.current.Load().Contains(text)
+}
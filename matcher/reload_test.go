@@ -0,0 +1,138 @@
+package matcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReadWords(t *testing.T) {
+	words, err := ReadWords(strings.NewReader("# 注释\n 敏感词 \n\nfoo\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"敏感词", "foo"}; !reflect.DeepEqual(words, want) {
+		t.Errorf("ReadWords() = %q, want %q", words, want)
+	}
+	if _, err := ReadWords(strings.NewReader("ok\n\xff\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("ReadWords() error = %v", err)
+	}
+}
+
+// writeWords 先写临时文件再重命名，模拟词表的原子更新
+func writeWords(t *testing.T, path, content string) {
+	t.Helper()
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReloader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	writeWords(t, path, "apple\n")
+	r, err := NewReloader(path, WithCaseFold(SimpleCaseFold))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Contains("APPLE pie") || r.Contains("banana") {
+		t.Fatal("initial dictionary is wrong")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reports := make(chan error, 8)
+	go r.Watch(ctx, 5*time.Millisecond, func(err error) {
+		reports <- err
+	})
+
+	// 查询在重新加载期间持续进行，只能看到旧词典或新词典
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for ctx.Err() == nil {
+			if got := r.Scan("apple banana"); len(got) == 0 {
+				t.Error("Scan() returned no match during reload")
+				return
+			}
+		}
+	}()
+
+	wait := func() error {
+		t.Helper()
+		select {
+		case err := <-reports:
+			return err
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for reload")
+			return nil
+		}
+	}
+
+	writeWords(t, path, "apple\nbanana\n")
+	if err := wait(); err != nil {
+		t.Fatalf("reload error = %v", err)
+	}
+	if got := r.Scan("apple banana"); !reflect.DeepEqual(got, []string{"apple", "banana"}) {
+		t.Errorf("Scan() = %v", got)
+	}
+
+	// 加载失败时保留当前词典
+	writeWords(t, path, "# 空词表\n")
+	if err := wait(); err == nil {
+		t.Fatal("empty word list should fail")
+	}
+	if r.LastError() == nil || !r.Contains("banana") {
+		t.Errorf("LastError() = %v, Contains(banana) = %v", r.LastError(), r.Contains("banana"))
+	}
+	writeWords(t, path, "banana\ncherry\n")
+	if err := wait(); err != nil {
+		t.Fatalf("reload error = %v", err)
+	}
+	if r.LastError() != nil || r.Contains("apple") || !r.Contains("cherry") {
+		t.Error("dictionary was not replaced")
+	}
+
+	// 文件被删除时保留当前词典
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := wait(); err == nil {
+		t.Fatal("missing file should fail")
+	}
+	if m, ok := r.FindFirst("cherry"); !ok || m.Pattern != "cherry" {
+		t.Errorf("FindFirst() = %+v, %v", m, ok)
+	}
+	cancel()
+	wg.Wait()
+}
+
+func TestReloaderBuild(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if _, err := NewReloader(path); err == nil {
+		t.Error("NewReloader() should fail for missing file")
+	}
+	writeWords(t, path, "foo\n")
+	r, err := NewReloader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Build([]string{"bar"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.FindAll("foo bar"); len(got) != 1 || got[0].Pattern != "bar" {
+		t.Errorf("FindAll() = %+v", got)
+	}
+	if err := r.Reload(); err != nil || !r.Contains("foo") {
+		t.Errorf("Reload() = %v", err)
+	}
+}
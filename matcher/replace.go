@@ -0,0 +1,46 @@
+package matcher
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// ReplaceAllFunc 将文本中匹配到的词替换为fn的返回值
+//
+// 无论构建时选择的匹配语义如何，替换总是使用 LeftmostLongest 选出不重叠的匹配，
+// 例如同时存在 "测试" 和 "测试测" 时，"测试测试" 中会替换 "测试测"，剩余的 "试" 保持不变。
+func (a *ACTree) ReplaceAllFunc(text string, fn func(Match) string) string {
+	matches := resolveMatches(a.scanMatches(text), LeftmostLongest)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(text))
+	last := 0
+	for _, m := range matches {
+		sb.WriteString(text[last:m.Start])
+		sb.WriteString(fn(m))
+		last = m.End
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+// ReplaceAll 将文本中匹配到的词替换为固定的字符串
+func (a *ACTree) ReplaceAll(text string, replacement string) string {
+	return a.ReplaceAllFunc(text, func(Match) string {
+		return replacement
+	})
+}
+
+// Mask 将文本中匹配到的词的每个字符替换为mask，例如把 "敏感词" 替换为 "***"
+func (a *ACTree) Mask(text string, mask rune) string {
+	m := string(mask)
+	if !utf8.ValidRune(mask) {
+		m = string(utf8.RuneError)
+	}
+	return a.ReplaceAllFunc(text, func(match Match) string {
+		return strings.Repeat(m, match.RuneEnd-match.RuneStart)
+	})
+}
@@ -0,0 +1,65 @@
+package matcher
+
+import "testing"
+
+// 测试敏感词替换
+func TestReplace(t *testing.T) {
+	ac := NewAc()
+	ac.Build([]string{"测试", "测试测", "敏感词", "感", "bad"})
+
+	tests := []struct {
+		name     string
+		text     string
+		mask     string // Mask('*') 的结果
+		replaced string // ReplaceAll("[x]") 的结果
+	}{
+		{
+			name:     "无匹配",
+			text:     "你好，世界！",
+			mask:     "你好，世界！",
+			replaced: "你好，世界！",
+		},
+		{
+			name:     "重叠词取最左最长",
+			text:     "测试测试",
+			mask:     "***试",
+			replaced: "[x]试",
+		},
+		{
+			name:     "包含关系",
+			text:     "这是敏感词，感谢",
+			mask:     "这是***，*谢",
+			replaced: "这是[x]，[x]谢",
+		},
+		{
+			name:     "中英混合",
+			text:     "bad测试badbad",
+			mask:     "***********",
+			replaced: "[x][x][x][x]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ac.Mask(tt.text, '*'); got != tt.mask {
+				t.Errorf("Mask() = %q, want %q", got, tt.mask)
+			}
+			if got := ac.ReplaceAll(tt.text, "[x]"); got != tt.replaced {
+				t.Errorf("ReplaceAll() = %q, want %q", got, tt.replaced)
+			}
+		})
+	}
+}
+
+// 测试使用回调计算替换内容
+func TestReplaceAllFunc(t *testing.T) {
+	ac := NewAc()
+	ac.Build([]string{"苹果", "香蕉"})
+
+	got := ac.ReplaceAllFunc("我喜欢苹果和香蕉", func(m Match) string {
+		return "<" + m.Pattern + ">"
+	})
+	if want := "我喜欢<苹果>和<香蕉>"; got != want {
+		t.Errorf("ReplaceAllFunc() = %q, want %q", got, want)
+	}
+}
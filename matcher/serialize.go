@@ -0,0 +1,707 @@
+package matcher
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"slices"
+)
+
+// 序列化格式
+//
+// 所有结构使用相同的外层格式：4字节类型标识 + 2字节版本号（小端）+ 数据 + 4字节CRC-32C校验和（小端，覆盖之前的所有字节）。
+// 数据部分的整数使用变长编码，节点按层次遍历顺序编号，子节点编号由遍历顺序隐含，不单独存储。
+// 序列化数据不包含配置中的函数和表（可忽略字符、边界要求函数、字符等价表、拼音表），
+// 加载时必须传入与构建时相同的配置。配置摘要包含字符等价表的校验和，数据中还记录模式串中可忽略字符的校验和，
+// 加载时还会用配置重新计算每个模式串的边界要求，任何不一致都会导致加载失败。
+// 可忽略字符只检查模式串中出现的字符，判断函数对其他字符的结果不同时无法发现。
+//
+// 加载时会重建节点和指针，不支持多个进程以只读方式共享同一份内存映射（mmap）的数据。
+
+// serialVersion 当前的序列化格式版本
+const serialVersion = 1
+
+var (
+	magicAC     = [4]byte{'M', 'S', 'A', 'C'}
+	magicACTree = [4]byte{'M', 'S', 'A', 'T'}
+	magicTrie   = [4]byte{'M', 'S', 'T', 'R'}
+
+	castagnoli = crc32.MakeTable(crc32.Castagnoli)
+)
+
+var (
+	// ErrInvalidData 序列化数据的类型标识、版本号或校验和不正确，或者内容已损坏
+	ErrInvalidData = errors.New("matcher: invalid serialized data")
+	// ErrOptionsMismatch 加载时传入的配置与构建时的配置不一致
+	ErrOptionsMismatch = errors.New("matcher: options differ from serialized automaton")
+)
+
+// encoder 序列化数据的编码器
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) uint(v uint64) {
+	e.buf = binary.AppendUvarint(e.buf, v)
+}
+
+func (e *encoder) int(v int) {
+	e.buf = binary.AppendVarint(e.buf, int64(v))
+}
+
+func (e *encoder) bool(v bool) {
+	if v {
+		e.buf = append(e.buf, 1)
+	} else {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func (e *encoder) string(s string) {
+	e.uint(uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+func (e *encoder) strings(ss []string) {
+	e.uint(uint64(len(ss)))
+	for _, s := range ss {
+		e.string(s)
+	}
+}
+
+func (e *encoder) ints(vs []int) {
+	e.uint(uint64(len(vs)))
+	for _, v := range vs {
+		e.int(v)
+	}
+}
+
+// options 写入配置摘要
+func (e *encoder) options(o *options) {
+	e.uint(uint64(o.kind))
+	e.uint(uint64(o.fold))
+	e.uint(uint64(o.norm))
+	e.uint(uint64(o.pinyin))
+	e.bool(o.variants != nil)
+	if o.variants != nil {
+		e.uint(uint64(variantDigest(o.variants)))
+	}
+	e.bool(o.ignore != nil)
+	if o.ignore != nil {
+		e.int(o.maxGap)
+	}
+	e.bool(o.boundary != nil)
+}
+
+// variantDigest 返回字符等价表的校验和
+func variantDigest(t *VariantTable) uint32 {
+	runes := make([]rune, 0, len(t.canon))
+	for r := range t.canon {
+		runes = append(runes, r)
+	}
+	slices.Sort(runes)
+	buf := make([]byte, 0, len(runes)*8)
+	for _, r := range runes {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(r))
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(t.canon[r]))
+	}
+	return crc32.Checksum(buf, castagnoli)
+}
+
+// ignoreDigest 返回模式串和拼音形式中可忽略字符的校验和
+func ignoreDigest(ignore func(rune) bool, patterns []string, forms []pinyinEntry) uint32 {
+	seen := make(map[rune]bool)
+	var runes []rune
+	check := func(s string) {
+		for _, r := range s {
+			if !seen[r] {
+				seen[r] = true
+				if ignore(r) {
+					runes = append(runes, r)
+				}
+			}
+		}
+	}
+	for _, p := range patterns {
+		check(p)
+	}
+	for _, f := range forms {
+		check(f.text)
+	}
+	slices.Sort(runes)
+	buf := make([]byte, 0, len(runes)*4)
+	for _, r := range runes {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(r))
+	}
+	return crc32.Checksum(buf, castagnoli)
+}
+
+// ignored 启用可忽略字符时写入模式串中可忽略字符的校验和
+func (e *encoder) ignored(o *options, patterns []string, forms []pinyinEntry) {
+	if o.ignore != nil {
+		e.uint(uint64(ignoreDigest(o.ignore, patterns, forms)))
+	}
+}
+
+func (e *encoder) forms(forms []pinyinEntry) {
+	e.uint(uint64(len(forms)))
+	for _, f := range forms {
+		e.uint(uint64(f.index))
+		e.string(f.text)
+		e.uint(uint64(f.length))
+		e.uint(uint64(f.form))
+	}
+}
+
+func (e *encoder) bounds(bs boundaries) {
+	e.bool(bs != nil)
+	e.uint(uint64(len(bs)))
+	for _, b := range bs {
+		e.uint(uint64(b))
+	}
+}
+
+// finish 加上类型标识、版本号和校验和后写入w
+func (e *encoder) finish(w io.Writer, magic [4]byte) (int64, error) {
+	data := make([]byte, 0, len(e.buf)+10)
+	data = append(data, magic[:]...)
+	data = binary.LittleEndian.AppendUint16(data, serialVersion)
+	data = append(data, e.buf...)
+	data = binary.LittleEndian.AppendUint32(data, crc32.Checksum(data, castagnoli))
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// decoder 序列化数据的解码器，遇到错误后所有读取都返回零值，错误保存在err中
+type decoder struct {
+	buf []byte
+	err error
+}
+
+// newDecoder 读取r中的全部数据并检查类型标识、版本号和校验和
+func newDecoder(r io.Reader, magic [4]byte) (*decoder, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 10 || !bytes.Equal(data[:4], magic[:]) {
+		return nil, fmt.Errorf("%w: unknown type", ErrInvalidData)
+	}
+	if v := binary.LittleEndian.Uint16(data[4:]); v != serialVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidData, v)
+	}
+	body, sum := data[:len(data)-4], binary.LittleEndian.Uint32(data[len(data)-4:])
+	if crc32.Checksum(body, castagnoli) != sum {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidData)
+	}
+	return &decoder{buf: body[6:]}, nil
+}
+
+// fail 记录数据损坏错误
+func (d *decoder) fail(what string) {
+	if d.err == nil {
+		d.err = fmt.Errorf("%w: %s", ErrInvalidData, what)
+	}
+}
+
+func (d *decoder) uint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.fail("truncated integer")
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) int() int {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		d.fail("truncated integer")
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return int(v)
+}
+
+// count 读取元素个数，每个元素至少占用一个字节，因此个数不能超过剩余的数据长度
+func (d *decoder) count() int {
+	n := d.uint()
+	if n > uint64(len(d.buf)) {
+		d.fail("count out of range")
+		return 0
+	}
+	return int(n)
+}
+
+// below 读取小于limit的非负整数
+func (d *decoder) below(limit int) int {
+	v := d.uint()
+	if v >= uint64(limit) {
+		d.fail("value out of range")
+		return 0
+	}
+	return int(v)
+}
+
+func (d *decoder) bool() bool {
+	return d.below(2) == 1
+}
+
+func (d *decoder) string() string {
+	n := d.count()
+	if d.err != nil {
+		return ""
+	}
+	s := string(d.buf[:n])
+	d.buf = d.buf[n:]
+	return s
+}
+
+func (d *decoder) strings() []string {
+	ss := make([]string, d.count())
+	for i := range ss {
+		ss[i] = d.string()
+	}
+	return ss
+}
+
+func (d *decoder) ints() []int {
+	vs := make([]int, d.count())
+	for i := range vs {
+		vs[i] = d.int()
+	}
+	return vs
+}
+
+// options 读取配置摘要并与o比较
+func (d *decoder) options(o *options) {
+	var e encoder
+	e.options(o)
+	if d.err != nil {
+		return
+	}
+	if len(d.buf) < len(e.buf) || !bytes.Equal(d.buf[:len(e.buf)], e.buf) {
+		d.err = ErrOptionsMismatch
+		return
+	}
+	d.buf = d.buf[len(e.buf):]
+}
+
+// forms 读取拼音形式，patterns为模式串数量
+func (d *decoder) forms(patterns int) []pinyinEntry {
+	forms := make([]pinyinEntry, d.count())
+	for i := range forms {
+		forms[i] = pinyinEntry{
+			index:  d.below(patterns),
+			text:   d.string(),
+			length: int(d.uint()),
+			form:   PinyinForm(d.below(int(PinyinFull|PinyinInitials) + 1)),
+		}
+	}
+	return forms
+}
+
+// bounds 读取边界要求，patterns为模式串数量
+func (d *decoder) bounds(patterns int) boundaries {
+	present := d.bool()
+	n := d.count()
+	if (present && n != patterns) || (!present && n != 0) {
+		d.fail("boundary count mismatch")
+	}
+	if !present {
+		return nil
+	}
+	bs := make(boundaries, n)
+	for i := range bs {
+		bs[i] = Boundary(d.below(1 << 8))
+	}
+	return bs
+}
+
+// ignored 启用可忽略字符时读取模式串中可忽略字符的校验和并与按配置计算的结果比较
+func (d *decoder) ignored(o *options, patterns []string, forms []pinyinEntry) {
+	if o.ignore == nil {
+		return
+	}
+	sum := d.uint()
+	if d.err == nil && sum != uint64(ignoreDigest(o.ignore, patterns, forms)) {
+		d.err = ErrOptionsMismatch
+	}
+}
+
+// boundary 检查按配置计算的第index个模式串的边界要求是否与序列化的边界要求一致
+func (d *decoder) boundary(o *options, bs boundaries, index int, pattern string) {
+	if d.err == nil && bs != nil && o.boundary(index, pattern) != bs[index] {
+		d.err = ErrOptionsMismatch
+	}
+}
+
+// link 读取第i个节点的失败指针。失败指针指向深度更小的节点，按层次遍历编号时小于i，
+// 根节点的失败指针指向自身，否则扫描时可能陷入循环
+func (d *decoder) link(i int) int {
+	v := d.uint()
+	if v >= uint64(max(i, 1)) {
+		d.fail("invalid fail link")
+		return 0
+	}
+	return int(v)
+}
+
+// id 读取输出集合中的编号，patterns和forms为模式串和拼音形式的数量
+func (d *decoder) id(patterns, forms int) int {
+	id := d.int()
+	if id >= patterns || id < formID(forms-1) {
+		d.fail("pattern index out of range")
+		return 0
+	}
+	return id
+}
+
+// finish 检查数据是否已经全部读取
+func (d *decoder) finish() error {
+	if d.err == nil && len(d.buf) != 0 {
+		d.fail("trailing data")
+	}
+	return d.err
+}
+
+// WriteTo 将构建好的AC自动机（Trie树、失败指针和输出集合）写入w，实现 io.WriterTo 接口
+// 必须在 Build 或 BuildFail 之后调用，编译的DFA转移表不会被写入
+func (ac *AC) WriteTo(w io.Writer) (int64, error) {
+	if ac.dirty || ac.root.fail == nil {
+		return 0, errors.New("matcher: AC fail links are not built")
+	}
+	var e encoder
+	e.options(&ac.opts)
+	e.strings(ac.patterns)
+	e.ints(ac.lengths)
+	e.uint(uint64(ac.maxLen))
+	e.forms(ac.forms)
+	e.ignored(&ac.opts, ac.patterns, ac.forms)
+	e.bounds(ac.bounds)
+
+	// 字符映射按字符索引顺序写入字符
+	runes := make([]rune, ac.maxChar)
+	for r, class := range ac.charMap {
+		runes[class] = r
+	}
+	e.uint(uint64(ac.maxChar))
+	for _, r := range runes[1:] {
+		e.uint(uint64(r))
+	}
+	e.bool(ac.dense)
+
+	// 按层次遍历编号
+	nodes := []*ACNode{ac.root}
+	ids := map[*ACNode]int{ac.root: 0}
+	for i := 0; i < len(nodes); i++ {
+		nodes[i].eachChild(func(_ uint16, child *ACNode) {
+			ids[child] = len(nodes)
+			nodes = append(nodes, child)
+		})
+	}
+	e.uint(uint64(len(nodes)))
+	for _, node := range nodes {
+		e.bool(node.isEnd)
+		if node.isEnd {
+			e.int(node.index)
+		}
+		e.uint(uint64(ids[node.fail]))
+		var classes []uint16
+		node.eachChild(func(class uint16, _ *ACNode) {
+			classes = append(classes, class)
+		})
+		e.uint(uint64(len(classes)))
+		for _, class := range classes {
+			e.uint(uint64(class))
+		}
+		e.ints(node.output)
+	}
+	return e.finish(w, magicAC)
+}
+
+// ReadAC 从r中加载 WriteTo 写入的AC自动机，opts必须与构建时的配置相同
+func ReadAC(r io.Reader, opts ...Option) (*AC, error) {
+	d, err := newDecoder(r, magicAC)
+	if err != nil {
+		return nil, err
+	}
+	ac := NewAC(opts...)
+	d.options(&ac.opts)
+	ac.patterns = d.strings()
+	ac.lengths = d.ints()
+	if len(ac.lengths) != len(ac.patterns) {
+		d.fail("length count mismatch")
+	}
+	ac.maxLen = int(d.uint())
+	ac.forms = d.forms(len(ac.patterns))
+	d.ignored(&ac.opts, ac.patterns, ac.forms)
+	ac.bounds = d.bounds(len(ac.patterns))
+	for i, pattern := range ac.patterns {
+		// 被删除的模式串置为空串，不再检查
+		if pattern != "" {
+			d.boundary(&ac.opts, ac.bounds, i, pattern)
+		}
+	}
+
+	if ac.maxChar = d.below(maxAlphabet + 1); ac.maxChar == 0 {
+		d.fail("empty alphabet")
+	}
+	for class := 1; class < ac.maxChar && d.err == nil; class++ {
+		ac.charMap[rune(d.below(1<<21))] = uint16(class)
+	}
+	ac.dense = d.bool()
+
+	n := d.count()
+	if d.err != nil || n == 0 {
+		d.fail("missing root node")
+		return nil, d.err
+	}
+	nodes := make([]*ACNode, n)
+	for i := range nodes {
+		nodes[i] = ac.newNode()
+	}
+	next := 1
+	for i, node := range nodes {
+		if node.isEnd = d.bool(); node.isEnd {
+			node.index = d.id(len(ac.patterns), len(ac.forms))
+		}
+		node.fail = nodes[d.link(i)]
+		for k := d.count(); k > 0 && d.err == nil; k-- {
+			class := d.below(ac.maxChar)
+			if class == int(otherClass) || next >= n {
+				d.fail("invalid child")
+				break
+			}
+			node.setChild(uint16(class), nodes[next])
+			next++
+		}
+		node.output = node.output[:0]
+		for k := d.count(); k > 0 && d.err == nil; k-- {
+			node.output = append(node.output, d.id(len(ac.patterns), len(ac.forms)))
+		}
+		if d.err != nil {
+			return nil, d.err
+		}
+	}
+	if next != n {
+		d.fail("unreachable nodes")
+	}
+	if err := d.finish(); err != nil {
+		return nil, err
+	}
+	ac.root = nodes[0]
+	return ac, nil
+}
+
+// WriteTo 将构建好的AC自动机（Trie树、失败指针和输出集合）写入w，实现 io.WriterTo 接口
+func (a *ACTree) WriteTo(w io.Writer) (int64, error) {
+	var e encoder
+	e.options(&a.opts)
+	e.strings(a.words)
+	e.ints(a.lengths)
+	e.uint(uint64(a.maxLen))
+	e.forms(a.forms)
+	e.ignored(&a.opts, a.words, a.forms)
+	e.bounds(a.bounds)
+
+	// 按层次遍历编号，子节点按字符排序以保证输出稳定
+	nodes := []*node{a.root}
+	ids := map[*node]int{a.root: 0}
+	keys := make([][]rune, 0, 1)
+	for i := 0; i < len(nodes); i++ {
+		runes := make([]rune, 0, len(nodes[i].child))
+		for r := range nodes[i].child {
+			runes = append(runes, r)
+		}
+		slices.Sort(runes)
+		keys = append(keys, runes)
+		for _, r := range runes {
+			child := nodes[i].child[r]
+			ids[child] = len(nodes)
+			nodes = append(nodes, child)
+		}
+	}
+	e.uint(uint64(len(nodes)))
+	for i, n := range nodes {
+		e.bool(n.isEnd)
+		if n.isEnd {
+			e.int(n.index)
+		}
+		e.uint(uint64(ids[n.fail]))
+		e.uint(uint64(len(keys[i])))
+		for _, r := range keys[i] {
+			e.uint(uint64(r))
+		}
+		e.ints(n.output)
+	}
+	return e.finish(w, magicACTree)
+}
+
+// ReadAc 从r中加载 ACTree.WriteTo 写入的AC自动机，opts必须与构建时的配置相同
+func ReadAc(r io.Reader, opts ...Option) (*ACTree, error) {
+	d, err := newDecoder(r, magicACTree)
+	if err != nil {
+		return nil, err
+	}
+	a := NewAc(opts...)
+	d.options(&a.opts)
+	a.words = d.strings()
+	a.lengths = d.ints()
+	if len(a.lengths) != len(a.words) {
+		d.fail("length count mismatch")
+	}
+	a.maxLen = int(d.uint())
+	a.forms = d.forms(len(a.words))
+	d.ignored(&a.opts, a.words, a.forms)
+	a.bounds = d.bounds(len(a.words))
+	for i, word := range a.words {
+		d.boundary(&a.opts, a.bounds, i, word)
+	}
+
+	n := d.count()
+	if d.err != nil || n == 0 {
+		d.fail("missing root node")
+		return nil, d.err
+	}
+	nodes := make([]*node, n)
+	for i := range nodes {
+		nodes[i] = newNode()
+	}
+	next := 1
+	for i, nd := range nodes {
+		if nd.isEnd = d.bool(); nd.isEnd {
+			nd.index = d.id(len(a.words), len(a.forms))
+		}
+		nd.fail = nodes[d.link(i)]
+		for k := d.count(); k > 0 && d.err == nil; k-- {
+			r := rune(d.below(1 << 21))
+			if next >= n {
+				d.fail("invalid child")
+				break
+			}
+			nd.child[r] = nodes[next]
+			a.charSet[r] = true
+			next++
+		}
+		for k := d.count(); k > 0 && d.err == nil; k-- {
+			nd.output = append(nd.output, d.id(len(a.words), len(a.forms)))
+		}
+		if d.err != nil {
+			return nil, d.err
+		}
+	}
+	if next != n {
+		d.fail("unreachable nodes")
+	}
+	if err := d.finish(); err != nil {
+		return nil, err
+	}
+	a.root = nodes[0]
+	return a, nil
+}
+
+// WriteTo 将Trie树写入w，实现 io.WriterTo 接口
+func (t *Trie) WriteTo(w io.Writer) (int64, error) {
+	var e encoder
+	e.options(&t.opts)
+	e.uint(uint64(t.size))
+	e.bounds(t.bounds)
+
+	nodes := []*TrieNode{t.root}
+	keys := make([][]rune, 0, 1)
+	for i := 0; i < len(nodes); i++ {
+		runes := make([]rune, 0, len(nodes[i].children))
+		for r := range nodes[i].children {
+			runes = append(runes, r)
+		}
+		slices.Sort(runes)
+		keys = append(keys, runes)
+		for _, r := range runes {
+			nodes = append(nodes, nodes[i].children[r])
+		}
+	}
+	e.uint(uint64(len(nodes)))
+	var values []string
+	for i, n := range nodes {
+		e.bool(n.isEnd)
+		if n.isEnd {
+			e.uint(uint64(n.index))
+			e.string(n.value)
+			values = append(values, n.value)
+		}
+		e.uint(uint64(len(keys[i])))
+		for _, r := range keys[i] {
+			e.uint(uint64(r))
+		}
+	}
+	// Trie树不单独保存模式串列表，可忽略字符的校验和写在节点之后
+	e.ignored(&t.opts, values, nil)
+	return e.finish(w, magicTrie)
+}
+
+// ReadTrie 从r中加载 Trie.WriteTo 写入的Trie树，opts必须与构建时的配置相同
+func ReadTrie(r io.Reader, opts ...Option) (*Trie, error) {
+	d, err := newDecoder(r, magicTrie)
+	if err != nil {
+		return nil, err
+	}
+	t := NewTrie(opts...)
+	if err := t.opts.noPinyin("Trie"); err != nil {
+		return nil, err
+	}
+	d.options(&t.opts)
+	t.size = int(d.uint())
+	t.bounds = d.bounds(t.size)
+
+	n := d.count()
+	if d.err != nil || n == 0 {
+		d.fail("missing root node")
+		return nil, d.err
+	}
+	nodes := make([]*TrieNode, n)
+	for i := range nodes {
+		nodes[i] = NewTrieNode()
+	}
+	next := 1
+	var values []string
+	for _, node := range nodes {
+		if node.isEnd = d.bool(); node.isEnd {
+			node.index = d.below(t.size)
+			node.value = d.string()
+			d.boundary(&t.opts, t.bounds, node.index, node.value)
+			values = append(values, node.value)
+		}
+		for k := d.count(); k > 0 && d.err == nil; k-- {
+			r := rune(d.below(1 << 21))
+			if next >= n {
+				d.fail("invalid child")
+				break
+			}
+			node.children[r] = nodes[next]
+			next++
+		}
+		if d.err != nil {
+			return nil, d.err
+		}
+	}
+	if next != n {
+		d.fail("unreachable nodes")
+	}
+	d.ignored(&t.opts, values, nil)
+	if err := d.finish(); err != nil {
+		return nil, err
+	}
+	t.root = nodes[0]
+	return t, nil
+}
@@ -0,0 +1,237 @@
+package matcher
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// serialMatcher 支持序列化的 Matcher 实现
+type serialMatcher interface {
+	Matcher
+	io.WriterTo
+}
+
+// roundTrip 序列化后重新加载
+func roundTrip(t *testing.T, m serialMatcher, opts ...Option) Matcher {
+	t.Helper()
+	var buf bytes.Buffer
+	n, err := m.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Fatalf("WriteTo() = %d, wrote %d bytes", n, buf.Len())
+	}
+	var loaded Matcher
+	switch m.(type) {
+	case *AC:
+		loaded, err = ReadAC(&buf, opts...)
+	case *ACTree:
+		loaded, err = ReadAc(&buf, opts...)
+	case *Trie:
+		loaded, err = ReadTrie(&buf, opts...)
+	}
+	if err != nil {
+		t.Fatalf("%T load error = %v", m, err)
+	}
+	return loaded
+}
+
+func TestSerializeRoundTrip(t *testing.T) {
+	patterns := []string{"he", "she", "his", "hers", "", "敏感词", "Hello", "he"}
+	texts := []string{"ushers his", "这是敏感词，minganci mgc", "HELLO hello", "", "中文测试\xff"}
+	for _, opts := range [][]Option{
+		nil,
+		{WithCaseFold(FullCaseFold), WithNormalization(NormalizeAll)},
+		{WithMatchKind(LeftmostLongest), WithIgnorable(IsNoise, 2)},
+		{WithPinyin(PinyinFull|PinyinInitials, nil), WithBoundary(BoundaryWord)},
+		{WithVariants(DefaultVariantTable())},
+	} {
+		ac, err := BuildAC(patterns, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tree := NewAc(opts...)
+		tree.Build(patterns)
+		matchers := []serialMatcher{ac, tree}
+		if newOptions(opts).pinyin == PinyinNone {
+			// Trie 不支持拼音形式
+			matchers = append(matchers, BuildTrie(patterns, opts...))
+		}
+		for _, m := range matchers {
+			loaded := roundTrip(t, m, opts...)
+			for _, text := range texts {
+				if got, want := loaded.FindAll(text), m.FindAll(text); !reflect.DeepEqual(got, want) {
+					t.Errorf("%T FindAll(%q) = %+v, want %+v", m, text, got, want)
+				}
+			}
+		}
+	}
+}
+
+// 测试加载后的AC自动机可以继续增量修改和编译DFA
+func TestSerializeACIncremental(t *testing.T) {
+	ac, err := BuildAC([]string{"abc", "bc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := roundTrip(t, ac).(*AC)
+	if err := loaded.Verify(); err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+	if _, err := loaded.Add("c"); err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Remove("abc") {
+		t.Fatal("Remove() = false")
+	}
+	if err := loaded.Verify(); err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+	if !loaded.CompileDFA(0) || len(loaded.FindAll("abc")) != 2 {
+		t.Errorf("FindAll() = %+v", loaded.FindAll("abc"))
+	}
+
+	// 插入后未构建失败指针时不能序列化
+	loaded.Insert("x")
+	if _, err := loaded.WriteTo(io.Discard); err == nil {
+		t.Error("WriteTo() should fail before BuildFail")
+	}
+}
+
+func TestSerializeErrors(t *testing.T) {
+	ac, err := BuildAC([]string{"foo", "bar"}, WithCaseFold(SimpleCaseFold))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err := ac.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	if _, err := ReadAC(bytes.NewReader(data)); !errors.Is(err, ErrOptionsMismatch) {
+		t.Errorf("ReadAC() without options error = %v", err)
+	}
+	if _, err := ReadTrie(bytes.NewReader(data), WithCaseFold(SimpleCaseFold)); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("ReadTrie() error = %v", err)
+	}
+	for i := range data {
+		corrupt := bytes.Clone(data)
+		corrupt[i] ^= 0x40
+		if _, err := ReadAC(bytes.NewReader(corrupt), WithCaseFold(SimpleCaseFold)); !errors.Is(err, ErrInvalidData) {
+			t.Errorf("corrupt byte %d: error = %v", i, err)
+		}
+	}
+	for _, n := range []int{0, 5, len(data) - 1} {
+		if _, err := ReadAC(bytes.NewReader(data[:n]), WithCaseFold(SimpleCaseFold)); !errors.Is(err, ErrInvalidData) {
+			t.Errorf("truncated to %d bytes: error = %v", n, err)
+		}
+	}
+}
+
+// 测试校验和正确但失败指针或输出集合损坏的数据加载失败
+func TestSerializeCorruptLinks(t *testing.T) {
+	load := func(m serialMatcher) error {
+		var buf bytes.Buffer
+		if _, err := m.WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		// WriteTo 按修改后的结构重新计算校验和
+		var err error
+		switch m.(type) {
+		case *AC:
+			_, err = ReadAC(&buf)
+		default:
+			_, err = ReadAc(&buf)
+		}
+		return err
+	}
+	for _, corrupt := range []func(a, b *ACNode){
+		func(a, b *ACNode) { a.fail = a },
+		func(a, b *ACNode) { a.fail = b },
+		func(a, b *ACNode) { b.output = []int{2} },
+		func(a, b *ACNode) { b.output = []int{-1} },
+	} {
+		ac, err := BuildAC([]string{"ab", "b"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var a *ACNode
+		ac.root.eachChild(func(_ uint16, child *ACNode) {
+			if a == nil {
+				a = child
+			}
+		})
+		var b *ACNode
+		a.eachChild(func(_ uint16, child *ACNode) { b = child })
+		corrupt(a, b)
+		if err := load(ac); !errors.Is(err, ErrInvalidData) {
+			t.Errorf("ReadAC() error = %v, want ErrInvalidData", err)
+		}
+	}
+	for _, corrupt := range []func(a, b *node){
+		func(a, b *node) { a.fail = a },
+		func(a, b *node) { a.fail = b },
+		func(a, b *node) { b.output = []int{2} },
+		func(a, b *node) { b.output = []int{-1} },
+	} {
+		tree := NewAc()
+		tree.Build([]string{"ab", "b"})
+		a := tree.root.child['a']
+		corrupt(a, a.child['b'])
+		if err := load(tree); !errors.Is(err, ErrInvalidData) {
+			t.Errorf("ReadAc() error = %v, want ErrInvalidData", err)
+		}
+	}
+}
+
+// 测试加载时使用内容不同的字符等价表、可忽略字符集合或边界要求时加载失败
+// 可忽略字符只比较模式串中出现的字符，"x.y" 中的 '.' 只被 IsNoise 忽略
+func TestSerializeOptionsContent(t *testing.T) {
+	other := NewVariantTable()
+	other.Add('台', '臺')
+	patterns := []string{"台湾", "ab", "x.y"}
+	for _, tt := range []struct {
+		name        string
+		built, load []Option
+	}{
+		{"variants", []Option{WithVariants(DefaultVariantTable())}, []Option{WithVariants(other)}},
+		{"ignore", []Option{WithIgnorable(IsNoise, 1)}, []Option{WithIgnorable(IgnoreRunes("*"), 1)}},
+		{"boundary", []Option{WithBoundary(BoundaryWord)}, []Option{WithBoundary(BoundaryLine)}},
+		{"pattern boundary", []Option{WithBoundary(BoundaryWord)}, []Option{WithPatternBoundary(func(i int, _ string) Boundary {
+			if i == 1 {
+				return BoundaryNone
+			}
+			return BoundaryWord
+		})}},
+	} {
+		ac, _ := BuildAC(patterns, tt.built...)
+		tree := NewAc(tt.built...)
+		tree.Build(patterns)
+		for _, m := range []serialMatcher{ac, tree, BuildTrie(patterns, tt.built...)} {
+			var buf bytes.Buffer
+			if _, err := m.WriteTo(&buf); err != nil {
+				t.Fatal(err)
+			}
+			data := buf.Bytes()
+			var err error
+			switch m.(type) {
+			case *AC:
+				_, err = ReadAC(bytes.NewReader(data), tt.load...)
+			case *ACTree:
+				_, err = ReadAc(bytes.NewReader(data), tt.load...)
+			case *Trie:
+				_, err = ReadTrie(bytes.NewReader(data), tt.load...)
+			}
+			if !errors.Is(err, ErrOptionsMismatch) {
+				t.Errorf("%s: %T load error = %v", tt.name, m, err)
+			}
+			// 相同内容的配置可以加载
+			roundTrip(t, m, tt.built...)
+		}
+	}
+}
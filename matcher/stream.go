@@ -0,0 +1,182 @@
+package matcher
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// streamBufferSize ScanReader 每次读取的字节数
+const streamBufferSize = 32 << 10
+
+// Stream AC自动机的流式扫描器
+//
+// Stream 在多次调用 Write 之间保存当前的自动机状态和已消费的字节数、rune数，
+// 因此跨越分块边界的匹配也能被正确报告，匹配位置为相对整个输入流的绝对偏移。
+// 分块边界切断的UTF-8字符会暂存到下一块到来时再处理。
+// 流式扫描总是报告所有重叠的匹配，按结束位置升序回调。
+// 有结束边界要求的匹配需要等到下一个字符到来（或 Flush）时才能确定是否报告。
+type Stream struct {
+	ac       *AC
+	state    *ACNode           // 当前自动机状态
+	offset   int               // 已处理的字节数
+	runes    int               // 已处理的rune数
+	pending  [utf8.UTFMax]byte // 上一块末尾不完整的UTF-8字节
+	npending int               // pending 中的有效字节数
+	fn       func(Match) bool  // 匹配回调
+	stopped  bool              // 回调是否要求停止扫描
+	ring     foldRing          // 启用大小写折叠、规范化或边界要求时记录变换后字符的位置
+	held     []Match           // 等待下一个字符以判断结束边界的匹配
+}
+
+// NewStream 创建流式扫描器，每个匹配都会调用fn，fn返回false时停止扫描
+func (ac *AC) NewStream(fn func(Match) bool) *Stream {
+	s := &Stream{ac: ac, state: ac.root, fn: fn}
+	if ac.opts.generic() {
+		s.ring = newFoldRing(ac.maxLen)
+	}
+	return s
+}
+
+// Write 扫描下一块输入，实现 io.Writer 接口
+// 回调要求停止后返回 io.ErrShortWrite，之后的输入都会被忽略
+func (s *Stream) Write(chunk []byte) (int, error) {
+	if s.stopped {
+		return 0, io.ErrShortWrite
+	}
+	i := 0
+
+	// 先补全上一块末尾不完整的字符
+	for s.npending > 0 && i < len(chunk) && !s.stopped {
+		old := s.npending
+		n := copy(s.pending[old:], chunk[i:])
+		if !utf8.FullRune(s.pending[:old+n]) {
+			// 当前块太短，仍然无法组成完整的字符
+			s.npending = old + n
+			i += n
+			break
+		}
+		r, size := utf8.DecodeRune(s.pending[:old+n])
+		s.step(r, size)
+		if size >= old {
+			i += size - old
+			s.npending = 0
+		} else {
+			// 非法字节按单字节处理，剩余的暂存字节重新解码
+			copy(s.pending[:], s.pending[size:old])
+			s.npending = old - size
+		}
+	}
+
+	for i < len(chunk) && !s.stopped {
+		if !utf8.FullRune(chunk[i:]) {
+			s.npending = copy(s.pending[:], chunk[i:])
+			break
+		}
+		r, size := utf8.DecodeRune(chunk[i:])
+		s.step(r, size)
+		i += size
+	}
+	if s.stopped {
+		return len(chunk), io.ErrShortWrite
+	}
+	return len(chunk), nil
+}
+
+// Flush 处理输入流末尾残留的不完整UTF-8字节，每个字节按 utf8.RuneError 处理，
+// 并把当前位置作为文本结尾报告等待结束边界判断的匹配
+func (s *Stream) Flush() {
+	for i := 0; i < s.npending && !s.stopped; i++ {
+		s.step(utf8.RuneError, 1)
+	}
+	s.npending = 0
+	s.release(edgeText)
+}
+
+// Reset 重置扫描器状态，从新的输入流开始扫描
+func (s *Stream) Reset() {
+	s.state = s.ac.root
+	s.offset = 0
+	s.runes = 0
+	s.npending = 0
+	s.stopped = false
+	s.held = s.held[:0]
+	s.ring.n, s.ring.space, s.ring.gap, s.ring.prev = 0, false, 0, edgeText
+}
+
+// Offset 返回已处理的字节数（不含暂存的不完整字符）
+func (s *Stream) Offset() int {
+	return s.offset
+}
+
+// step 消费一个字符并报告以该字符结尾的所有匹配
+func (s *Stream) step(r rune, size int) {
+	start, runeStart := s.offset, s.runes
+	s.offset += size
+	s.runes++
+	if s.ac.opts.generic() {
+		fn := s.fn
+		if s.ac.bounds != nil {
+			if s.release(classify(r)); s.stopped {
+				return
+			}
+			fn = s.hold
+		}
+		var ok bool
+		s.state, ok = s.ac.foldStep(s.state, &s.ring, r, start, s.offset, runeStart, fn)
+		s.stopped = s.stopped || !ok
+		return
+	}
+	s.state = s.ac.findNextState(s.state, r)
+	for _, index := range s.state.output {
+		if !s.fn(s.ac.match(index, s.offset, s.runes)) {
+			s.stopped = true
+			return
+		}
+	}
+}
+
+// hold 暂存有结束边界要求的匹配，其他匹配直接报告
+// foldStep 已经检查过起始边界
+func (s *Stream) hold(m Match) bool {
+	if s.ac.bounds[m.Index]&boundaryEnd != 0 {
+		s.held = append(s.held, m)
+		return true
+	}
+	return s.fn(m)
+}
+
+// release 根据匹配之后的字符类别after判断并报告暂存的匹配
+// 暂存的匹配都在上一个字符处结束，上一个字符的类别记录在 ring.prev 中
+func (s *Stream) release(after edgeClass) {
+	for _, m := range s.held {
+		if s.stopped {
+			break
+		}
+		if s.ac.bounds[m.Index].acceptEnd(s.ring.prev, after) && !s.fn(m) {
+			s.stopped = true
+		}
+	}
+	s.held = s.held[:0]
+}
+
+// ScanReader 从r中流式读取并扫描全部输入，每个匹配都会调用fn，fn返回false时停止扫描
+// 内存占用与输入大小无关，适合扫描大文件
+func (ac *AC) ScanReader(r io.Reader, fn func(Match) bool) error {
+	s := ac.NewStream(fn)
+	buf := make([]byte, streamBufferSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if _, werr := s.Write(buf[:n]); werr != nil {
+				return nil
+			}
+		}
+		if err == io.EOF {
+			s.Flush()
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package matcher
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// 测试分块扫描的结果与整体搜索一致
+func TestStream(t *testing.T) {
+	patterns := []string{"he", "she", "hers", "你好", "世界🌍", "测试测", "\xe4"}
+	text := "ushers你好👋世界🌍测试测试测\xe4\xb8 x\xff你"
+	ac, err := BuildAC(patterns)
+	if err != nil {
+		t.Fatalf("BuildAC() error = %v", err)
+	}
+	want := ac.FindAll(text)
+
+	// 按各种块大小切分文本，覆盖切断多字节字符的情况
+	for size := 1; size <= len(text); size++ {
+		var got []Match
+		s := ac.NewStream(func(m Match) bool {
+			got = append(got, m)
+			return true
+		})
+		for i := 0; i < len(text); i += size {
+			s.Write([]byte(text[i:min(i+size, len(text))]))
+		}
+		s.Flush()
+		sortMatches(got)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("chunk size %d: got %+v, want %+v", size, got, want)
+		}
+		if s.Offset() != len(text) {
+			t.Errorf("chunk size %d: Offset() = %d, want %d", size, s.Offset(), len(text))
+		}
+	}
+}
+
+// 测试从io.Reader流式扫描
+func TestScanReader(t *testing.T) {
+	ac, _ := BuildAC([]string{"错误", "ERROR", "超时"})
+	text := strings.Repeat("INFO 正常\nERROR 连接超时\n", 1000) + "错误"
+
+	var got []Match
+	err := ac.ScanReader(iotest.HalfReader(strings.NewReader(text)), func(m Match) bool {
+		got = append(got, m)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("ScanReader() error = %v", err)
+	}
+	sortMatches(got)
+	if want := ac.FindAll(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("ScanReader() found %d matches, want %d", len(got), len(want))
+	}
+	for _, m := range got {
+		if text[m.Start:m.End] != m.Pattern {
+			t.Fatalf("text[%d:%d] = %q, want %q", m.Start, m.End, text[m.Start:m.End], m.Pattern)
+		}
+	}
+
+	// 回调返回false时提前停止
+	count := 0
+	ac.ScanReader(strings.NewReader(text), func(m Match) bool {
+		count++
+		return count < 3
+	})
+	if count != 3 {
+		t.Errorf("ScanReader() stopped after %d matches, want 3", count)
+	}
+
+	// 读取错误会被返回
+	if err := ac.ScanReader(iotest.ErrReader(iotest.ErrTimeout), func(Match) bool { return true }); err != iotest.ErrTimeout {
+		t.Errorf("ScanReader() error = %v, want %v", err, iotest.ErrTimeout)
+	}
+}
@@ -0,0 +1,308 @@
+package matcher
+
+import (
+	"iter"
+	"unicode/utf8"
+)
+
+// TrieNode 定义Trie树的节点结构
+type TrieNode struct {
+	children map[rune]*TrieNode // 子节点映射表
+	isEnd    bool               // 标记是否是单词结尾
+	value    string             // 存储该节点对应的完整字符串
+	index    int                // 该单词在插入顺序中的下标
+}
+
+// NewTrieNode 创建新的Trie节点
+func NewTrieNode() *TrieNode {
+	return &TrieNode{
+		children: make(map[rune]*TrieNode),
+		isEnd:    false,
+	}
+}
+
+// Trie 定义Trie树结构
+type Trie struct {
+	root   *TrieNode
+	size   int        // 已插入的单词数量
+	opts   options    // 构建配置，不使用拼音形式
+	bounds boundaries // 单词的边界要求
+}
+
+// NewTrie 创建新的Trie树
+func NewTrie(opts ...Option) *Trie {
+	return &Trie{
+		root: NewTrieNode(),
+		opts: newOptions(opts),
+	}
+}
+
+// Insert 向Trie树中插入一个单词，单词下标为已插入的单词数量
+func (t *Trie) Insert(word string) {
+	t.insert(word, t.size)
+	t.bounds = t.opts.appendBoundaries(t.bounds, word)
+	t.size++
+}
+
+// insert 插入下标为index的单词，重复的单词保留第一次出现的下标
+func (t *Trie) insert(word string, index int) {
+	node := t.root
+	runes := t.opts.foldString(word)
+	if len(runes) == 0 {
+		// 变换后为空的单词（例如只由可忽略字符组成）不会产生匹配
+		return
+	}
+	for _, r := range runes {
+		if node.children[r] == nil {
+			node.children[r] = NewTrieNode()
+		}
+		node = node.children[r]
+	}
+	if !node.isEnd {
+		node.isEnd = true
+		node.value = word
+		node.index = index
+	}
+}
+
+// BuildTrie 预处理构建Trie树，配置不受支持时返回空的Trie树，需要检查错误时使用 Build
+func BuildTrie(patterns []string, opts ...Option) *Trie {
+	trie := NewTrie(opts...)
+	trie.Build(patterns)
+	return trie
+}
+
+// SearchList 在文本中搜索所有模式串，返回匹配的字符串列表
+// 匹配语义不是 MatchAll 时只返回选中的不重叠匹配中的字符串
+func (t *Trie) SearchList(text string) []string {
+	result := make([]string, 0, 64) // 预分配空间
+	seen := make(map[string]bool)   // 用于去重
+	if t.opts.kind != MatchAll || t.opts.generic() {
+		for _, m := range t.FindAll(text) {
+			if !seen[m.Pattern] {
+				result = append(result, m.Pattern)
+				seen[m.Pattern] = true
+			}
+		}
+		return result
+	}
+	runes := []rune(text)
+	n := len(runes)
+
+	// 对文本中的每个位置进行匹配
+	for i := 0; i < n; i++ {
+		node := t.root
+		for j := i; j < n; j++ {
+			if node.children[runes[j]] == nil {
+				break
+			}
+			node = node.children[runes[j]]
+			if node.isEnd && !seen[node.value] {
+				result = append(result, node.value)
+				seen[node.value] = true
+			}
+		}
+	}
+	return result
+}
+
+// Search 在文本中搜索所有模式串出现的位置
+// 返回一个map，key是模式串，value是该模式串在文本中出现的所有位置的切片
+// 匹配语义不是 MatchAll 时只返回选中的不重叠匹配
+func (t *Trie) Search(text string) map[string][]int {
+	result := make(map[string][]int)
+	if t.opts.kind != MatchAll || t.opts.generic() {
+		for _, m := range t.FindAll(text) {
+			result[m.Pattern] = append(result[m.Pattern], m.RuneStart)
+		}
+		return result
+	}
+	runes := []rune(text)
+	n := len(runes)
+
+	// 对文本中的每个位置进行匹配
+	for i := 0; i < n; i++ {
+		node := t.root
+		for j := i; j < n; j++ {
+			if node.children[runes[j]] == nil {
+				break
+			}
+			node = node.children[runes[j]]
+			if node.isEnd {
+				if result[node.value] == nil {
+					result[node.value] = make([]int, 0)
+				}
+				result[node.value] = append(result[node.value], i)
+			}
+		}
+	}
+	return result
+}
+
+// Build 使用模式串集合构建Trie树，会丢弃之前构建的内容
+// 不支持拼音形式，配置了 WithPinyin 时返回 ErrUnsupportedOption
+func (t *Trie) Build(patterns []string) error {
+	if err := t.opts.noPinyin("Trie"); err != nil {
+		return err
+	}
+	t.root = NewTrieNode()
+	for i, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		t.insert(pattern, i)
+	}
+	t.size = len(patterns)
+	t.bounds = t.opts.appendBoundaries(nil, patterns...)
+	return nil
+}
+
+// FindFirst 返回文本中最先出现的匹配
+func (t *Trie) FindFirst(text string) (Match, bool) {
+	if t.opts.kind != MatchAll {
+		// 不重叠语义下最先的匹配即结果中的第一个
+		if matches := t.FindAll(text); len(matches) > 0 {
+			return matches[0], true
+		}
+		return Match{}, false
+	}
+	var best Match
+	found := false
+	if t.opts.generic() {
+		t.scanFold(text, nil, func(m Match) bool {
+			// 起始位置超过当前最优结束位置后不可能再找到更优的匹配
+			if found && m.Start >= best.End {
+				return false
+			}
+			if better(m, best, found) {
+				best, found = m, true
+			}
+			return true
+		})
+		return best, found
+	}
+
+	// 起始位置超过当前最优结束位置后不可能再找到更优的匹配
+	runeStart := 0
+	for start := 0; start < len(text) && (!found || start < best.End); {
+		node := t.root
+		runeEnd := runeStart
+		for end := start; end < len(text); {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if node.children[r] == nil {
+				break
+			}
+			node = node.children[r]
+			end += size
+			runeEnd++
+			if node.isEnd {
+				m := Match{Index: node.index, Pattern: node.value, Start: start, End: end, RuneStart: runeStart, RuneEnd: runeEnd}
+				if better(m, best, found) {
+					best, found = m, true
+				}
+				break
+			}
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		start += size
+		runeStart++
+	}
+	return best, found
+}
+
+// Each 按文本顺序（起始位置升序，起始位置相同时短的在前）对每个匹配调用fn，fn返回false时停止匹配
+// 未启用字符变换和边界要求时匹配过程不分配内存
+func (t *Trie) Each(text string, fn func(Match) bool) {
+	t.each(text, nil, fn)
+}
+
+// each 同 Each，check不为nil时在从字节偏移pos开始匹配之前调用check(pos)，返回false时停止匹配
+func (t *Trie) each(text string, check func(pos int) bool, fn func(Match) bool) {
+	if t.opts.generic() {
+		t.scanFold(text, check, fn)
+		return
+	}
+
+	// 对文本中的每个位置进行匹配
+	runeStart := 0
+	for start := 0; start < len(text); {
+		if check != nil && !check(start) {
+			return
+		}
+		node := t.root
+		runeEnd := runeStart
+		for end := start; end < len(text); {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if node.children[r] == nil {
+				break
+			}
+			node = node.children[r]
+			end += size
+			runeEnd++
+			if node.isEnd {
+				if !fn(Match{Index: node.index, Pattern: node.value, Start: start, End: end, RuneStart: runeStart, RuneEnd: runeEnd}) {
+					return
+				}
+			}
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		start += size
+		runeStart++
+	}
+}
+
+// Matches 返回按文本顺序产生所有匹配的迭代器，同 Each
+func (t *Trie) Matches(text string) iter.Seq[Match] {
+	return func(yield func(Match) bool) {
+		t.Each(text, yield)
+	}
+}
+
+// FindAll 按匹配语义返回文本中的匹配
+func (t *Trie) FindAll(text string) []Match {
+	var result []Match
+	t.Each(text, func(m Match) bool {
+		result = append(result, m)
+		return true
+	})
+	return resolveMatches(result, t.opts.kind)
+}
+
+// Contains 判断文本中是否包含任一模式串
+func (t *Trie) Contains(text string) bool {
+	_, found := t.FirstMatch(text)
+	return found
+}
+
+// scanFold 启用大小写折叠、规范化或边界要求时从每个原文字符开始沿Trie树匹配变换后的文本，
+// 按文本顺序对每个覆盖完整原文字符并满足边界要求的匹配调用fn，fn返回false时停止匹配
+// check 的含义同 each
+func (t *Trie) scanFold(text string, check func(pos int) bool, fn func(Match) bool) {
+	var fr foldReader
+	runeStart := 0
+	for start := 0; start < len(text); {
+		if check != nil && !check(start) {
+			return
+		}
+		fr.reset(&t.opts, text, start, runeStart)
+		node := t.root
+		for r, ok := fr.read(); ok; r, ok = fr.read() {
+			// 起始字符变换后为空时匹配不能从该字符开始，跳过的可忽略字符超过上限时匹配中断
+			if (fr.start != start && node == t.root) || fr.broken {
+				break
+			}
+			if node = node.children[r]; node == nil {
+				break
+			}
+			if node.isEnd && fr.last() {
+				m := Match{Index: node.index, Pattern: node.value, Start: start, End: fr.next, RuneStart: runeStart, RuneEnd: fr.runes}
+				if t.bounds.accept(text, m) && !fn(m) {
+					return
+				}
+			}
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		start += size
+		runeStart++
+	}
+}
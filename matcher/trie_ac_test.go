@@ -0,0 +1,426 @@
+package matcher
+
+import (
+	"iter"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type multiPatternTestCase struct {
+	name     string
+	text     string
+	patterns []string
+	ac       *ACTree
+	trie     *Trie
+	arrayAC  *AC
+	dfaAC    *AC
+	byteAC   *ByteAC
+	da       *DoubleArray
+}
+
+// 生成重复文本
+func generateRepeatedText(base string, count int) string {
+	return strings.Repeat(base, count)
+}
+
+// 生成模式串数组
+func generatePatterns(base []string, mutations int) []string {
+	patterns := make([]string, 0, len(base)*mutations)
+	patterns = append(patterns, base...)
+
+	// 生成变种模式串
+	for _, p := range base {
+		for i := 0; i < mutations-1; i++ {
+			patterns = append(patterns, p+strconv.Itoa(i))
+		}
+	}
+	return patterns
+}
+
+func BenchmarkMultiPatternMatch(b *testing.B) {
+	// 基础模式串集合
+	basePatterns := []string{
+		"你好", "世界", "测试", "模式串",
+		"hello", "world", "test", "pattern",
+		"你好世界", "helloworld",
+	}
+
+	testCases := []multiPatternTestCase{
+		{
+			name:     "短文本少模式串",
+			text:     "你好，世界！Hello, World!",
+			patterns: basePatterns[:4],
+		},
+		{
+			name:     "短文本多模式串",
+			text:     "你好，世界！Hello, World!",
+			patterns: generatePatterns(basePatterns, 5),
+		},
+		{
+			name:     "中文文本重复模式",
+			text:     generateRepeatedText("你好世界测试模式串", 100),
+			patterns: generatePatterns([]string{"你好", "世界", "测试"}, 3),
+		},
+		{
+			name:     "英文文本重复模式",
+			text:     generateRepeatedText("hello world test pattern", 100),
+			patterns: generatePatterns([]string{"hello", "world", "test"}, 3),
+		},
+		{
+			name:     "混合文本大量模式",
+			text:     generateRepeatedText("你好hello世界world测试test模式串pattern", 50),
+			patterns: generatePatterns(basePatterns, 10),
+		},
+		{
+			name:     "长文本少量模式",
+			text:     generateRepeatedText("这是一个很长的测试文本，包含中英文mixed content测试内容", 200),
+			patterns: basePatterns[:3],
+		},
+		{
+			name:     "长文本大量模式",
+			text:     generateRepeatedText("这是一个很长的测试文本，包含中英文mixed content测试内容", 200),
+			patterns: generatePatterns(basePatterns, 20),
+		},
+		{
+			name:     "模式串前缀重叠",
+			text:     generateRepeatedText("测试测试测和测试测试测", 100),
+			patterns: []string{"测试", "测试测", "测试测试", "测试测试测"},
+		},
+		{
+			name:     "模式串后缀重叠",
+			text:     generateRepeatedText("测试测试测和测试测试测试", 100),
+			patterns: []string{"试测", "试测试", "试测试测", "试测试测试"},
+		},
+		{
+			name:     "特殊字符混合",
+			text:     generateRepeatedText("你好👋世界🌍测试✨模式串💻", 50),
+			patterns: []string{"👋世界🌍", "测试✨", "模式串💻", "你好👋"},
+		},
+		{
+			name:     "HTML文本",
+			text:     generateRepeatedText("<div class='test'>这是测试文本</div><p>这是段落</p>", 50),
+			patterns: []string{"<div", "</div>", "class", "测试文本", "<p>", "</p>"},
+		},
+		{
+			name:     "URL文本",
+			text:     generateRepeatedText("https://example.com/测试?param=value&中文=测试", 50),
+			patterns: []string{"https://", "example", ".com", "测试", "param=", "中文="},
+		},
+		{
+			name:     "JSON文本",
+			text:     generateRepeatedText(`{"name":"测试","value":"test","中文":"数据"}`, 50),
+			patterns: []string{`"name"`, `"value"`, `"test"`, `"中文"`, `"数据"`},
+		},
+	}
+
+	// 预处理所有测试用例
+	for i := range testCases {
+		ac := NewAc()
+		ac.Build(testCases[i].patterns)
+		testCases[i].ac = ac
+
+		testCases[i].trie = BuildTrie(testCases[i].patterns)
+		testCases[i].arrayAC, _ = BuildAC(testCases[i].patterns)
+		testCases[i].dfaAC, _ = BuildAC(testCases[i].patterns)
+		testCases[i].dfaAC.CompileDFA(0)
+		testCases[i].byteAC, _ = BuildByteAC(testCases[i].patterns)
+		testCases[i].da, _ = BuildDoubleArray(testCases[i].patterns)
+	}
+
+	// 运行基准测试
+	for _, tc := range testCases {
+		// Trie树测试
+		b.Run("Trie_"+tc.name+"_Patterns_"+strconv.Itoa(len(tc.patterns)), func(b *testing.B) {
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tc.trie.SearchList(tc.text)
+			}
+		})
+
+		// 双数组Trie树测试
+		b.Run("DATrie_"+tc.name+"_Patterns_"+strconv.Itoa(len(tc.patterns)), func(b *testing.B) {
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tc.da.SearchList(tc.text)
+			}
+		})
+
+		// 修改AC自动机测试，使用Scan方法
+		b.Run("AC_"+tc.name+"_Patterns_"+strconv.Itoa(len(tc.patterns)), func(b *testing.B) {
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tc.ac.Scan(tc.text)
+			}
+		})
+
+		// 双数组AC自动机测试
+		b.Run("DAAC_"+tc.name+"_Patterns_"+strconv.Itoa(len(tc.patterns)), func(b *testing.B) {
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tc.da.Scan(tc.text)
+			}
+		})
+
+		// 数组实现的AC自动机测试
+		b.Run("ArrayAC_"+tc.name+"_Patterns_"+strconv.Itoa(len(tc.patterns)), func(b *testing.B) {
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tc.arrayAC.Search(tc.text)
+			}
+		})
+
+		// 编译为DFA转移表的AC自动机测试
+		b.Run("DFAAC_"+tc.name+"_Patterns_"+strconv.Itoa(len(tc.patterns)), func(b *testing.B) {
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tc.dfaAC.Search(tc.text)
+			}
+		})
+
+		// 字节AC自动机测试，直接扫描原始字符串
+		b.Run("ByteAC_"+tc.name+"_Patterns_"+strconv.Itoa(len(tc.patterns)), func(b *testing.B) {
+			b.ReportAllocs()
+			count := 0
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tc.byteAC.Each(tc.text, func(m Match) bool {
+					count++
+					return true
+				})
+			}
+		})
+
+		// 回调和迭代器接口，不分配内存
+		b.Run("EachAC_"+tc.name+"_Patterns_"+strconv.Itoa(len(tc.patterns)), func(b *testing.B) {
+			count := 0
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tc.ac.Each(tc.text, func(m Match) bool {
+					count++
+					return true
+				})
+			}
+		})
+
+		b.Run("EachArrayAC_"+tc.name+"_Patterns_"+strconv.Itoa(len(tc.patterns)), func(b *testing.B) {
+			count := 0
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for range tc.arrayAC.Matches(tc.text) {
+					count++
+				}
+			}
+		})
+
+		b.Run("EachTrie_"+tc.name+"_Patterns_"+strconv.Itoa(len(tc.patterns)), func(b *testing.B) {
+			count := 0
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for range tc.trie.Matches(tc.text) {
+					count++
+				}
+			}
+		})
+	}
+}
+
+// 测试结果一致性
+func TestMatchConsistency(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		patterns []string
+	}{
+		{
+			name:     "基本匹配",
+			text:     "你好，世界！Hello, World!",
+			patterns: []string{"你好", "世界", "Hello", "World"},
+		},
+		{
+			name:     "重叠模式",
+			text:     "测试测试测试",
+			patterns: []string{"测试", "测试测", "测试测试"},
+		},
+		{
+			name:     "特殊字符",
+			text:     "你好👋世界🌍",
+			patterns: []string{"👋世界", "世界🌍", "你好👋"},
+		},
+		{
+			name:     "HTML内容",
+			text:     "<div>测试</div>",
+			patterns: []string{"<div>", "</div>", "测试"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ac := NewAc()
+			ac.Build(tc.patterns)
+			trie := BuildTrie(tc.patterns)
+
+			acResult := ac.FindAll(tc.text)
+			trieResult := trie.FindAll(tc.text)
+
+			// 比较结果
+			if len(acResult) != len(trieResult) {
+				t.Fatalf("Result count mismatch: AC=%v, Trie=%v", acResult, trieResult)
+			}
+
+			for i := range acResult {
+				if acResult[i] != trieResult[i] {
+					t.Errorf("Match mismatch at %d: AC=%+v, Trie=%+v", i, acResult[i], trieResult[i])
+				}
+				// 字节偏移可以直接切片原始文本
+				if got := tc.text[acResult[i].Start:acResult[i].End]; got != acResult[i].Pattern {
+					t.Errorf("text[%d:%d] = %q, want %q", acResult[i].Start, acResult[i].End, got, acResult[i].Pattern)
+				}
+			}
+		})
+	}
+}
+
+// 测试文本中大量出现模式串字符集之外的字符时AC与Trie结果一致
+func TestMatchConsistencyForeignChars(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		patterns []string
+	}{
+		{
+			name:     "英文外来字符",
+			text:     "xb yb ab zab",
+			patterns: []string{"ab"},
+		},
+		{
+			name:     "中文外来字符",
+			text:     "一个世界，另一个世界，乙界",
+			patterns: []string{"世界", "界"},
+		},
+		{
+			name:     "外来字符打断匹配",
+			text:     "测X试测试测Y试测",
+			patterns: []string{"测试", "试测"},
+		},
+		{
+			name:     "表情与外来字符",
+			text:     "😀👋🌍你👋世界🌍🙂世🌍",
+			patterns: []string{"👋世界", "世界🌍"},
+		},
+		{
+			name:     "全部为外来字符",
+			text:     strings.Repeat("abcdefg", 10),
+			patterns: []string{"你好", "世界"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ac, err := BuildAC(tc.patterns)
+			if err != nil {
+				t.Fatalf("BuildAC() error = %v", err)
+			}
+			trie := BuildTrie(tc.patterns)
+
+			acResult := ac.Search(tc.text)
+			trieResult := trie.Search(tc.text)
+
+			// 比较结果
+			if len(acResult) != len(trieResult) {
+				t.Errorf("Result count mismatch: AC=%v, Trie=%v", acResult, trieResult)
+			}
+
+			for pattern, acPos := range acResult {
+				triePos, exists := trieResult[pattern]
+				if !exists {
+					t.Errorf("Pattern %s found in AC but not in Trie", pattern)
+					continue
+				}
+
+				if len(acPos) != len(triePos) {
+					t.Errorf("Position count mismatch for pattern %s: AC=%v, Trie=%v",
+						pattern, acPos, triePos)
+					continue
+				}
+
+				// 比较位置
+				for i := range acPos {
+					if acPos[i] != triePos[i] {
+						t.Errorf("Position mismatch for pattern %s: AC=%d, Trie=%d",
+							pattern, acPos[i], triePos[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+// 测试回调和迭代器接口与 FindAll 一致、可以提前停止，并且不分配内存
+func TestEachMatches(t *testing.T) {
+	patterns := []string{"测试", "测试测", "试测", "hello", "he"}
+	text := "测试测试测 hello 测试"
+	tree := NewAc()
+	tree.Build(patterns)
+	ac, _ := BuildAC(patterns)
+	byteAC, _ := BuildByteAC(patterns)
+	type iterable interface {
+		FindAll(string) []Match
+		Each(string, func(Match) bool)
+		Matches(string) iter.Seq[Match]
+	}
+	for _, m := range []iterable{tree, ac, BuildTrie(patterns), byteAC} {
+		var got []Match
+		for match := range m.Matches(text) {
+			got = append(got, match)
+		}
+		sortMatches(got)
+		if want := m.FindAll(text); !reflect.DeepEqual(got, want) {
+			t.Errorf("%T Matches() = %v, want %v", m, got, want)
+		}
+
+		count := 0
+		m.Each(text, func(Match) bool {
+			count++
+			return count < 2
+		})
+		if count != 2 {
+			t.Errorf("%T Each() did not stop, count = %d", m, count)
+		}
+	}
+
+	// 通过接口调用时无法内联，因此直接使用具体类型检查内存分配
+	trie := BuildTrie(patterns)
+	count := 0
+	for name, fn := range map[string]func(){
+		"ACTree": func() {
+			for range tree.Matches(text) {
+				count++
+			}
+		},
+		"AC": func() {
+			for range ac.Matches(text) {
+				count++
+			}
+		},
+		"Trie": func() {
+			for range trie.Matches(text) {
+				count++
+			}
+		},
+		"ByteAC": func() {
+			byteAC.Each(text, func(Match) bool {
+				count++
+				return true
+			})
+		},
+	} {
+		if allocs := testing.AllocsPerRun(10, fn); allocs != 0 {
+			t.Errorf("%s allocs = %v", name, allocs)
+		}
+	}
+}
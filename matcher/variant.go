@@ -0,0 +1,143 @@
+package matcher
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+// VariantTable 字符等价表，例如繁简字对照表
+//
+// 同一组中的字符互相等价，匹配时都按组中的代表字符（最先加入该组的字符）比较，
+// 因此每个模式串都会自动匹配它的所有变体写法，例如 "发票" 可以匹配 "發票"。
+// 等价表在构建匹配器时生效，构建之后不应再修改。
+type VariantTable struct {
+	canon   map[rune]rune   // 字符到代表字符的映射
+	members map[rune][]rune // 代表字符到组内所有字符的映射
+}
+
+// NewVariantTable 创建空的字符等价表
+func NewVariantTable() *VariantTable {
+	return &VariantTable{
+		canon:   make(map[rune]rune),
+		members: make(map[rune][]rune),
+	}
+}
+
+// DefaultVariantTable 返回内置的常用繁简字对照表，每次调用返回新的等价表
+func DefaultVariantTable() *VariantTable {
+	t := NewVariantTable()
+	for _, group := range builtinVariants {
+		t.Add([]rune(group)...)
+	}
+	return t
+}
+
+// Add 将字符加入同一组，字符已经属于其他组时合并这些组
+// 合并后的代表字符为第一个已有组的代表字符，都不属于任何组时为第一个字符，少于两个字符时忽略
+func (t *VariantTable) Add(chars ...rune) {
+	if len(chars) < 2 {
+		return
+	}
+	root := chars[0]
+	for _, c := range chars {
+		if canon, ok := t.canon[c]; ok {
+			root = canon
+			break
+		}
+	}
+	t.ensure(root)
+	for _, c := range chars {
+		other := t.Canonical(c)
+		if other == root {
+			continue
+		}
+		t.ensure(other)
+		for _, m := range t.members[other] {
+			t.canon[m] = root
+		}
+		t.members[root] = append(t.members[root], t.members[other]...)
+		delete(t.members, other)
+	}
+}
+
+// ensure 确保代表字符r拥有自己的组
+func (t *VariantTable) ensure(r rune) {
+	if _, ok := t.members[r]; !ok {
+		t.members[r] = []rune{r}
+		t.canon[r] = r
+	}
+}
+
+// Canonical 返回字符所在组的代表字符，没有变体的字符返回自身
+func (t *VariantTable) Canonical(r rune) rune {
+	if c, ok := t.canon[r]; ok {
+		return c
+	}
+	return r
+}
+
+// Variants 返回与字符等价的所有字符（包括自身），代表字符在前
+func (t *VariantTable) Variants(r rune) []rune {
+	if members, ok := t.members[t.Canonical(r)]; ok {
+		return slices.Clone(members)
+	}
+	return []rune{r}
+}
+
+// Len 返回等价表中拥有变体的字符数量
+func (t *VariantTable) Len() int {
+	return len(t.canon)
+}
+
+// ReadVariantTable 从r中读取字符等价表
+//
+// 每行一组互相等价的字符，第一个字符作为代表字符，字符之间的空白会被忽略，
+// 因此可以直接读取 "发\t發 髮" 这样的OpenCC字符对照表。空行和以 # 开头的行会被跳过。
+func ReadVariantTable(r io.Reader) (*VariantTable, error) {
+	t := NewVariantTable()
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if !utf8.ValidString(text) {
+			return nil, fmt.Errorf("matcher: variant table line %d: invalid UTF-8", line)
+		}
+		var group []rune
+		for _, c := range text {
+			if unicode.IsSpace(c) {
+				continue
+			}
+			if c == '#' && len(group) == 0 {
+				break
+			}
+			group = append(group, c)
+		}
+		t.Add(group...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// LoadVariantTable 从文件中读取字符等价表，文件格式见 ReadVariantTable
+func LoadVariantTable(path string) (*VariantTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadVariantTable(f)
+}
+
+// WithVariants 设置字符等价表，默认不使用
+// BruteForce、KMP、Trie、ACTree 和 AC 支持该配置，按等价表变换后相同的模式串视为重复的模式串
+func WithVariants(t *VariantTable) Option {
+	return func(o *options) {
+		o.variants = t
+	}
+}
@@ -0,0 +1,74 @@
+package matcher
+
+// builtinVariants 内置的常用繁简字对照表，每项的第一个字符为简体字，其余为对应的繁体字或异体字
+var builtinVariants = []string{
+	"爱愛", "罢罷", "备備", "贝貝", "笔筆", "毕畢", "边邊", "宾賓", "补補", "财財",
+	"参參", "产產", "长長", "场場", "车車", "陈陳", "称稱", "诚誠", "迟遲", "齿齒",
+	"虫蟲", "处處", "传傳", "辞辭", "从從", "错錯", "达達", "带帶", "单單", "担擔",
+	"胆膽", "当當噹", "党黨", "导導", "灯燈", "邓鄧", "敌敵", "递遞", "点點", "电電",
+	"东東", "动動", "冻凍", "斗鬥", "独獨", "断斷", "对對", "队隊", "吨噸", "夺奪",
+	"儿兒", "尔爾", "发發髮", "范範", "飞飛", "费費", "丰豐", "风風", "冯馮", "凤鳳",
+	"妇婦", "复復複", "负負", "该該", "盖蓋", "干乾幹", "赶趕", "个個", "给給", "沟溝",
+	"构構", "购購", "谷穀", "顾顧", "关關", "观觀", "馆館", "广廣", "归歸", "贵貴",
+	"国國", "过過", "汉漢", "号號", "后後", "壶壺", "护護", "华華", "划劃", "画畫",
+	"话話", "怀懷", "坏壞", "欢歡", "环環", "还還", "换換", "黄黃", "会會", "汇匯彙",
+	"伙夥", "获獲穫", "货貨", "击擊", "机機", "鸡雞", "积積", "极極", "际際", "继繼",
+	"几幾", "计計", "记記", "纪紀", "价價", "驾駕", "坚堅", "间間", "简簡", "见見",
+	"剑劍", "将將", "讲講", "奖獎", "酱醬", "胶膠", "阶階", "节節", "杰傑", "结結",
+	"洁潔", "紧緊", "仅僅", "尽盡儘", "进進", "惊驚", "经經", "旧舊", "举舉", "剧劇",
+	"惧懼", "据據", "卷捲", "觉覺", "开開", "凯凱", "壳殼", "课課", "垦墾", "恳懇",
+	"库庫", "块塊", "宽寬", "况況", "亏虧", "矿礦", "困睏", "扩擴", "来來", "兰蘭",
+	"蓝藍", "篮籃", "览覽", "滥濫", "劳勞", "乐樂", "类類", "离離", "礼禮", "里裡裏",
+	"历歷曆", "丽麗", "厉厲", "励勵", "连連", "联聯", "脸臉", "炼煉鍊", "恋戀", "凉涼",
+	"粮糧", "两兩", "辆輛", "疗療", "了瞭", "邻鄰", "灵靈", "岭嶺", "龄齡", "刘劉",
+	"龙龍", "楼樓", "卢盧", "芦蘆", "炉爐", "陆陸", "录錄録", "虑慮", "乱亂", "论論",
+	"罗羅", "逻邏", "萝蘿", "妈媽", "马馬", "吗嗎", "买買", "卖賣", "满滿", "猫貓",
+	"么麼", "门門", "们們", "梦夢", "弥彌", "面麵", "庙廟", "灭滅", "鸣鳴", "难難",
+	"脑腦", "闹鬧", "内內", "拟擬", "鸟鳥", "宁寧", "农農", "浓濃", "欧歐", "盘盤",
+	"赔賠", "喷噴", "凭憑", "苹蘋", "评評", "扑撲", "仆僕", "朴樸", "齐齊", "气氣",
+	"弃棄", "迁遷", "钱錢", "枪槍", "墙牆", "桥橋", "亲親", "轻輕", "庆慶", "穷窮",
+	"区區", "权權", "劝勸", "确確", "让讓", "热熱", "认認", "荣榮", "软軟", "润潤",
+	"洒灑", "伞傘", "丧喪", "扫掃", "杀殺", "纱紗", "晒曬", "伤傷", "舍捨", "摄攝",
+	"设設", "审審", "声聲", "绳繩", "胜勝", "圣聖", "师師", "时時", "识識", "实實",
+	"势勢", "视視", "试試", "适適", "寿壽", "书書", "术術", "树樹", "帅帥", "双雙",
+	"说說", "丝絲", "松鬆", "虽雖", "随隨", "岁歲", "孙孫", "缩縮", "锁鎖", "台臺颱檯",
+	"态態", "谈談", "汤湯", "讨討", "体體", "条條", "铁鐵", "听聽", "厅廳", "头頭",
+	"图圖", "团團", "袜襪", "湾灣", "万萬", "网網", "为為", "违違", "伟偉", "卫衛",
+	"闻聞", "问問", "无無", "务務", "雾霧", "习習", "戏戲", "系係繫", "细細", "虾蝦",
+	"吓嚇", "显顯", "县縣", "现現", "线線", "宪憲", "乡鄉", "详詳", "响響", "项項",
+	"协協", "写寫", "谢謝", "兴興", "选選", "学學", "寻尋", "训訓", "压壓", "亚亞",
+	"严嚴", "盐鹽", "验驗", "阳陽", "养養", "样樣", "药藥", "爷爺", "叶葉", "页頁",
+	"业業", "医醫", "仪儀", "亿億", "艺藝", "忆憶", "义義", "议議", "异異", "阴陰",
+	"银銀", "饮飲", "隐隱", "应應", "营營", "拥擁", "优優", "忧憂", "邮郵", "犹猶",
+	"鱼魚", "语語", "与與", "誉譽", "狱獄", "预預", "员員", "园園", "远遠", "愿願",
+	"约約", "跃躍", "云雲", "运運", "杂雜", "灾災", "载載", "赞贊讚", "脏臟髒", "则則",
+	"择擇", "泽澤", "贼賊", "赠贈", "扎紮", "闸閘", "战戰", "张張", "涨漲", "帐帳賬",
+	"这這", "针針", "侦偵", "阵陣", "镇鎮", "证證", "郑鄭", "织織", "职職", "执執",
+	"纸紙", "质質", "制製", "钟鐘鍾", "种種", "众眾", "周週", "昼晝", "猪豬", "烛燭",
+	"筑築", "专專", "转轉", "庄莊", "装裝", "状狀", "准準", "资資", "总總", "纵縱",
+	"组組", "钻鑽", "赌賭", "弹彈", "诈詐", "骗騙", "贷貸", "贩販", "闭閉", "阅閱",
+	"闪閃", "闯闖", "闲閒閑", "访訪", "译譯", "诉訴", "词詞", "读讀", "请請", "调調",
+	"谁誰", "诗詩", "误誤", "诸諸", "诺諾", "谋謀", "谓謂", "谊誼", "讯訊", "红紅",
+	"级級", "纯純", "纳納", "纲綱", "纷紛", "练練", "终終", "绍紹", "绝絕", "统統",
+	"绿綠", "维維", "综綜", "编編", "缘緣", "钢鋼", "钥鑰", "铜銅", "锅鍋", "锋鋒",
+	"镜鏡", "铺鋪", "链鏈", "销銷", "锐銳", "贫貧", "贪貪", "贸貿", "赏賞", "赢贏",
+	"赛賽", "账賬", "顺順", "须須鬚", "顿頓", "领領", "频頻", "题題", "颜顏", "额額",
+	"驶駛", "驻駐", "骑騎", "驱驅", "鲜鮮", "鲁魯", "军軍", "轮輪", "较較", "辑輯",
+	"输輸", "辈輩", "规規", "饭飯", "饱飽", "饼餅", "饿餓", "尘塵", "岛島", "顶頂",
+	"伦倫", "伪偽", "侠俠", "俭儉", "债債", "倾傾", "偿償", "圆圓", "坛壇罈", "坝壩",
+	"垒壘", "夸誇", "奋奮", "妆妝", "宝寶", "尝嘗", "层層", "属屬", "币幣", "帮幫",
+	"废廢", "庐廬", "弯彎", "彻徹", "征徵", "怜憐", "惯慣", "愤憤", "懒懶",
+	"扬揚", "扰擾", "抚撫", "抢搶", "报報", "拨撥", "挂掛", "挡擋", "挤擠", "挥揮",
+	"捞撈", "损損", "捣搗", "掷擲", "揽攬", "携攜", "摆擺", "摇搖", "数數", "晓曉",
+	"暂暫", "杨楊", "标標", "栏欄", "档檔", "检檢", "残殘", "没沒", "泪淚", "泼潑",
+	"测測", "济濟", "浏瀏", "涂塗", "涛濤", "渐漸", "游遊", "湿濕", "滚滾", "烂爛",
+	"烦煩", "烧燒", "焕煥", "牵牽", "狭狹", "狮獅", "猎獵", "献獻", "玛瑪", "琐瑣",
+	"畅暢", "疯瘋", "痒癢", "监監", "盗盜", "睁睜", "矫矯", "码碼", "础礎", "碍礙",
+	"祷禱", "祸禍", "稳穩", "窃竊", "竞競", "笋筍", "笼籠", "签簽籤", "纠糾", "罚罰",
+	"聪聰", "肃肅", "胁脅", "脉脈", "脚腳", "脱脫", "腊臘", "舰艦", "艰艱", "芜蕪",
+	"苏蘇", "茧繭", "荐薦", "莲蓮", "萧蕭", "蔼藹", "虏虜", "袭襲", "触觸", "赵趙",
+	"趋趨", "践踐", "踪蹤", "轰轟", "辽遼", "迈邁", "遗遺", "酝醞", "释釋", "钉釘",
+	"铃鈴", "阀閥", "阁閣", "险險", "雏雛", "韦韋", "韩韓", "颗顆", "飘飄", "饥飢饑",
+	"馈饋", "驴驢", "骂罵", "骚騷", "鸭鴨", "鹅鵝", "鹰鷹", "麦麥", "龟龜", "丑醜",
+	"冲衝", "出齣", "只隻", "表錶", "余餘", "郁鬱", "致緻", "蒙矇濛懞", "千韆", "秋鞦",
+}
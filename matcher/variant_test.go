@@ -0,0 +1,104 @@
+package matcher
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestVariantTable(t *testing.T) {
+	table := NewVariantTable()
+	table.Add('发', '發')
+	table.Add('髮', '發')
+	table.Add('干', '乾')
+	table.Add('幹', '干')
+
+	if got := table.Canonical('髮'); got != '发' {
+		t.Errorf("Canonical('髮') = %q, want '发'", got)
+	}
+	if got := table.Variants('發'); !slices.Equal(got, []rune("发發髮")) {
+		t.Errorf("Variants('發') = %q", string(got))
+	}
+	if got := table.Variants('中'); !slices.Equal(got, []rune("中")) {
+		t.Errorf("Variants('中') = %q", string(got))
+	}
+	if got := table.Len(); got != 6 {
+		t.Errorf("Len() = %d, want 6", got)
+	}
+
+	// 合并两个已有的组
+	table.Add('發', '乾')
+	if got := table.Canonical('幹'); got != '发' {
+		t.Errorf("Canonical('幹') = %q after merge, want '发'", got)
+	}
+}
+
+func TestVariantMatch(t *testing.T) {
+	patterns := []string{"发票", "台湾", "代开"}
+	text := "代開發票，臺灣、台灣和台湾"
+	want := []Match{
+		{Index: 2, Pattern: "代开", Start: 0, End: 6, RuneStart: 0, RuneEnd: 2},
+		{Index: 0, Pattern: "发票", Start: 6, End: 12, RuneStart: 2, RuneEnd: 4},
+		{Index: 1, Pattern: "台湾", Start: 15, End: 21, RuneStart: 5, RuneEnd: 7},
+		{Index: 1, Pattern: "台湾", Start: 24, End: 30, RuneStart: 8, RuneEnd: 10},
+		{Index: 1, Pattern: "台湾", Start: 33, End: 39, RuneStart: 11, RuneEnd: 13},
+	}
+	opts := []Option{WithVariants(DefaultVariantTable())}
+	if ref := foldReference(patterns, text, opts...); !reflect.DeepEqual(ref, want) {
+		t.Fatalf("reference = %+v, want %+v", ref, want)
+	}
+	for name, m := range buildFoldMatchers(t, patterns, opts...) {
+		if got := m.FindAll(text); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s FindAll() = %+v, want %+v", name, got, want)
+		}
+	}
+
+	// 繁体的模式串同样匹配简体文本
+	a := NewAc(opts...)
+	a.Build([]string{"賭博"})
+	if got, want := a.Mask("禁止赌博和賭博", '*'), "禁止**和**"; got != want {
+		t.Errorf("Mask() = %q, want %q", got, want)
+	}
+}
+
+func TestReadVariantTable(t *testing.T) {
+	input := "# 繁简对照\n发\t發 髮\n\n后 後\n单\n"
+	table, err := ReadVariantTable(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadVariantTable() error = %v", err)
+	}
+	if got := table.Variants('髮'); !slices.Equal(got, []rune("发發髮")) {
+		t.Errorf("Variants('髮') = %q", string(got))
+	}
+	if got := table.Canonical('後'); got != '后' {
+		t.Errorf("Canonical('後') = %q", got)
+	}
+	if got := table.Len(); got != 5 {
+		t.Errorf("Len() = %d, want 5", got)
+	}
+
+	if _, err := ReadVariantTable(strings.NewReader("发發\n\xff\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("ReadVariantTable() error = %v, want line 2 error", err)
+	}
+}
+
+func TestLoadVariantTable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "variants.txt")
+	if err := os.WriteFile(path, []byte("云雲\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	table, err := LoadVariantTable(path)
+	if err != nil {
+		t.Fatalf("LoadVariantTable() error = %v", err)
+	}
+	ac, _ := BuildAC([]string{"白云"}, WithVariants(table))
+	if !ac.Contains("白雲") {
+		t.Error("Contains(\"白雲\") = false, want true")
+	}
+	if _, err := LoadVariantTable(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("LoadVariantTable() of missing file error = nil")
+	}
+}
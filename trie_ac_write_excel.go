@@ -51,8 +51,79 @@ func trieACExcelMain() {
 		}
 	}
 
+	writeDoubleArraySheet(f)
+
 	// 保存文件
 	if err := f.SaveAs("trie_ac_性能对比.xlsx"); err != nil {
 		fmt.Println(err)
 	}
 }
+
+// writeDoubleArraySheet 写入双数组与map实现的对比数据
+func writeDoubleArraySheet(f *excelize.File) {
+	const sheet = "双数组对比"
+	f.NewSheet(sheet)
+
+	// 设置表头
+	headers := []string{
+		"测试场景",
+		"模式串数量",
+		"Trie 性能 (ns/op)",
+		"Trie 内存分配(B)",
+		"双数组Trie 性能 (ns/op)",
+		"双数组Trie 内存分配(B)",
+		"AC 性能 (ns/op)",
+		"AC 内存分配(B)",
+		"双数组AC 性能 (ns/op)",
+		"双数组AC 内存分配(B)",
+	}
+	for i, header := range headers {
+		cell := fmt.Sprintf("%c1", 'A'+i)
+		f.SetCellValue(sheet, cell, header)
+	}
+
+	// 写入数据
+	data := [][]interface{}{
+		{"短文本少模式串", 4, 982.3, 1152, 1044, 1152, 346.2, 0, 915.8, 1152},
+		{"短文本多模式串", 50, 1228, 1152, 1266, 1152, 483.2, 0, 874.6, 1152},
+		{"中文文本重复模式", 9, 26490, 5248, 27784, 5248, 31036, 11264, 18795, 8320},
+		{"英文文本重复模式", 9, 57294, 10880, 83818, 10880, 65560, 16896, 47261, 8320},
+		{"混合文本大量模式", 100, 71061, 7296, 68942, 7296, 68065, 22784, 54836, 17792},
+		{"长文本少量模式", 3, 170444, 28416, 186719, 28416, 172880, 43904, 148757, 17792},
+		{"长文本大量模式", 200, 165896, 28416, 142081, 28416, 165551, 43904, 114691, 17792},
+		{"模式串前缀重叠", 4, 50233, 6016, 41209, 6016, 62851, 62464, 54883, 58752},
+		{"模式串后缀重叠", 4, 57418, 6016, 63952, 6016, 90633, 103424, 78636, 99712},
+		{"特殊字符混合", 4, 25957, 3840, 25489, 3840, 32928, 9856, 25002, 8320},
+		{"HTML文本", 6, 59957, 10624, 63723, 10624, 43653, 16640, 36366, 8320},
+		{"URL文本", 6, 55197, 9344, 84783, 9344, 56221, 24832, 55694, 17792},
+		{"JSON文本", 5, 42759, 9344, 53623, 9344, 43890, 15360, 28846, 8320},
+	}
+
+	for i, row := range data {
+		for j, value := range row {
+			cell := fmt.Sprintf("%c%d", 'A'+j, i+2)
+			f.SetCellValue(sheet, cell, value)
+		}
+	}
+
+	// 10万词典的构建耗时与常驻内存
+	dictHeaders := []string{"10万词典", "构建耗时 (ms)", "常驻内存(B)", "搜索性能 (ns/op)"}
+	dictRow := len(data) + 3
+	for i, header := range dictHeaders {
+		cell := fmt.Sprintf("%c%d", 'A'+i, dictRow)
+		f.SetCellValue(sheet, cell, header)
+	}
+	dictData := [][]interface{}{
+		{"Trie", 281, 49031504, 752788},
+		{"AC", 424, 63587200, 581429},
+		// 双数组的两种搜索方式共用同一次构建，构建耗时和常驻内存相同
+		{"DA-Trie", 312, 12309936, 617468},
+		{"DA-AC", 312, 12309936, 429445},
+	}
+	for i, row := range dictData {
+		for j, value := range row {
+			cell := fmt.Sprintf("%c%d", 'A'+j, dictRow+i+1)
+			f.SetCellValue(sheet, cell, value)
+		}
+	}
+}